@@ -14,6 +14,7 @@ import (
 
 	"github.com/goki/gi/oswin"
 	"github.com/goki/gi/oswin/key"
+	"github.com/goki/gi/oswin/mouse"
 	"github.com/goki/gi/units"
 	"github.com/goki/ki"
 	"github.com/goki/ki/ints"
@@ -50,6 +51,95 @@ const (
 
 //go:generate stringer -type=DialogState
 
+// ModalDialogStack is the stack of currently-open modal dialogs, with the
+// active (most recently opened) modal at the end.  Dialog.Open pushes modal
+// dialogs onto this stack and Close pops them, so that when a nested modal
+// closes, event capture is handed back to the modal beneath it.  Dialogs
+// closed out-of-order are removed from the middle of the stack.
+var ModalDialogStack []*Dialog
+
+// ModalDialogPush pushes given dialog onto the modal stack, making it the
+// active modal dialog
+func ModalDialogPush(dlg *Dialog) {
+	ModalDialogStack = append(ModalDialogStack, dlg)
+}
+
+// ModalDialogPop removes given dialog from the modal stack, wherever it is
+// (dialogs can be closed out-of-order) -- if it was the active modal, event
+// capture is restored to the next one down
+func ModalDialogPop(dlg *Dialog) {
+	sz := len(ModalDialogStack)
+	if sz == 0 {
+		return
+	}
+	wastop := ModalDialogStack[sz-1] == dlg
+	for i := sz - 1; i >= 0; i-- {
+		if ModalDialogStack[i] == dlg {
+			ModalDialogStack = append(ModalDialogStack[:i], ModalDialogStack[i+1:]...)
+			break
+		}
+	}
+	if !wastop {
+		return
+	}
+	top := ActiveModalDialog()
+	if top == nil {
+		ShowNextQueuedDialog()
+		return
+	}
+	if !top.IsSepWindow() && top.Win != nil && top.Win.CurPopup() != top.This() {
+		top.Win.SetNextPopup(top.This(), nil)
+	}
+}
+
+// ActiveModalDialog returns the currently-active modal dialog (top of the
+// modal stack), and nil if there is none
+func ActiveModalDialog() *Dialog {
+	sz := len(ModalDialogStack)
+	if sz == 0 {
+		return nil
+	}
+	return ModalDialogStack[sz-1]
+}
+
+// DialogsQueueModal determines whether modal dialogs opened while another
+// modal dialog is already open are queued and shown one at a time as each
+// closes, instead of stacking on top of each other -- useful when e.g.,
+// multiple errors fire in a row.  Off by default, as nested modal dialogs
+// (confirmations, error prompts from within a dialog) rely on stacking.
+var DialogsQueueModal = false
+
+// DialogQueueEntry holds a pending modal dialog and its saved Open arguments
+type DialogQueueEntry struct {
+	Dlg     *Dialog
+	X, Y    int
+	Vp      *Viewport2D
+	CfgFunc func()
+}
+
+// DialogQueue is the FIFO queue of pending modal dialogs, waiting for the
+// current modal dialog to close (see DialogsQueueModal)
+var DialogQueue []*DialogQueueEntry
+
+// PendingDialogCount returns the number of modal dialogs currently queued
+// waiting to be shown
+func PendingDialogCount() int {
+	return len(DialogQueue)
+}
+
+// ShowNextQueuedDialog opens the next queued modal dialog, if any -- called
+// automatically when the last open modal dialog closes -- returns false if
+// the queue is empty
+func ShowNextQueuedDialog() bool {
+	if len(DialogQueue) == 0 {
+		return false
+	}
+	qe := DialogQueue[0]
+	DialogQueue = DialogQueue[1:]
+	qe.Dlg.Open(qe.X, qe.Y, qe.Vp, qe.CfgFunc)
+	return true
+}
+
 // standard vertical space between elements in a dialog, in Ex units
 var StdDialogVSpace = float32(1)
 var StdDialogVSpaceUnits = units.Value{Val: StdDialogVSpace, Un: units.Ex, Dots: 0}
@@ -58,17 +148,90 @@ var StdDialogVSpaceUnits = units.Value{Val: StdDialogVSpace, Un: units.Ex, Dots:
 // be rendered in a separate window or on top of an existing one.
 type Dialog struct {
 	Viewport2D
-	Title     string      `desc:"title text displayed as the window title for the dialog"`
-	Prompt    string      `desc:"a prompt string displayed below the title"`
-	Modal     bool        `desc:"open the dialog in a modal state, blocking all other input"`
-	DefSize   image.Point `desc:"default size -- if non-zero, then this is used instead of doing an initial size computation -- can save a lot of time for complex dialogs -- sizes are remembered and used after first use anyway"`
-	State     DialogState `desc:"state of the dialog"`
-	SigVal    int64       `desc:"signal value that will be sent, if >= 0 (by default, DialogAccepted or DialogCanceled will be sent for standard Ok / Cancel buttons)"`
-	DialogSig ki.Signal   `json:"-" xml:"-" view:"-" desc:"signal for dialog -- sends a signal when opened, accepted, or canceled"`
+	Title                 string             `desc:"title text displayed as the window title for the dialog"`
+	Prompt                string             `desc:"a prompt string displayed below the title"`
+	Modal                 bool               `desc:"open the dialog in a modal state, blocking all other input"`
+	SepWindow             *bool              `json:"-" xml:"-" view:"-" desc:"per-dialog override of the global DialogsSepWindow setting -- nil (default) means use the global -- all open / close paths consult this via IsSepWindow"`
+	Details               string             `desc:"optional details text shown in a collapsible pane below the prompt (e.g., stack trace or full error message) -- hidden until the user expands it"`
+	DismissOnClickOutside bool               `desc:"for modeless in-window dialogs, dismiss (Cancel) the dialog when a click occurs outside of its bounds -- ignored for modal dialogs and separate-window dialogs"`
+	MaxContentHeight      units.Value        `desc:"optional maximum height for the dialog content frame -- if non-zero, content taller than this scrolls instead of growing the dialog, independent of screen size -- useful for inherently long content (logs, lists)"`
+	FixedSize             bool               `desc:"for separate-window dialogs, open the window at the computed size and set resize hints so the user cannot resize it -- dialogs are resizable by default"`
+	DefSize               image.Point        `desc:"default size -- if non-zero, then this is used instead of doing an initial size computation -- can save a lot of time for complex dialogs -- sizes are remembered and used after first use anyway"`
+	MinSize               Vec2D              `desc:"minimum size of the dialog in dots (see SetMinSize) -- non-zero components override the style-derived minimums in Open"`
+	MaxSize               Vec2D              `desc:"maximum size of the dialog in dots (see SetMaxSize) -- non-zero components cap the content-derived size in Open, with oversized content scrolling"`
+	State                 DialogState        `desc:"state of the dialog"`
+	SigVal                int64              `desc:"signal value that will be sent, if >= 0 (by default, DialogAccepted or DialogCanceled will be sent for standard Ok / Cancel buttons)"`
+	DefButton             string             `desc:"name of the button box button designated as the default action, activated by KeyFunAccept (Enter) from anywhere in the dialog -- empty means Enter calls Accept directly"`
+	DialogSig             ki.Signal          `json:"-" xml:"-" view:"-" desc:"signal for dialog -- sends a signal when opened, accepted, or canceled"`
+	AcceptCheck           func() error       `json:"-" xml:"-" view:"-" desc:"optional validation function run by Accept -- a non-nil error vetoes acceptance, showing the error and keeping the dialog open"`
+	ResultFunc            func() interface{} `json:"-" xml:"-" view:"-" desc:"optional function returning the user-set value(s) of the dialog, set by the standard dialog constructors -- read via Result, so callers do not depend on internal child widget names"`
+	OpenerWin             *Window            `json:"-" xml:"-" view:"-" desc:"window from which the dialog was opened, recorded by Open -- focus returns to it when a separate-window dialog closes"`
 }
 
 var KiT_Dialog = kit.Types.AddType(&Dialog{}, DialogProps)
 
+// IsSepWindow returns whether this dialog opens in a separate OS-level
+// window, using the per-dialog SepWindow override if set, and otherwise the
+// global DialogsSepWindow setting
+func (dlg *Dialog) IsSepWindow() bool {
+	if dlg.SepWindow != nil {
+		return *dlg.SepWindow
+	}
+	return DialogsSepWindow
+}
+
+// SetSepWindow sets the per-dialog override of the global DialogsSepWindow
+// setting -- must be called before Open
+func (dlg *Dialog) SetSepWindow(sep bool) {
+	dlg.SepWindow = &sep
+}
+
+// SetMinSize sets a minimum dialog size in dots -- non-zero components
+// override the style-derived MinWidth / MinHeight in Open, so a dialog with
+// tiny content still opens at a usable size -- must be called before Open
+func (dlg *Dialog) SetMinSize(sz Vec2D) {
+	dlg.MinSize = sz
+}
+
+// SetMaxSize sets a maximum dialog size in dots -- non-zero components cap
+// the content-derived size in Open, with oversized content scrolling within
+// the frame -- must be called before Open
+func (dlg *Dialog) SetMaxSize(sz Vec2D) {
+	dlg.MaxSize = sz
+}
+
+// ConstrainSize applies the MinSize / MaxSize constraints to given computed
+// dialog size, returning the constrained size and whether a max cap was
+// applied (in which case content should scroll)
+func (dlg *Dialog) ConstrainSize(vpsz image.Point) (image.Point, bool) {
+	if dlg.MinSize.X > 0 {
+		vpsz.X = ints.MaxInt(vpsz.X, int(dlg.MinSize.X))
+	}
+	if dlg.MinSize.Y > 0 {
+		vpsz.Y = ints.MaxInt(vpsz.Y, int(dlg.MinSize.Y))
+	}
+	capped := false
+	if dlg.MaxSize.X > 0 && vpsz.X > int(dlg.MaxSize.X) {
+		vpsz.X = int(dlg.MaxSize.X)
+		capped = true
+	}
+	if dlg.MaxSize.Y > 0 && vpsz.Y > int(dlg.MaxSize.Y) {
+		vpsz.Y = int(dlg.MaxSize.Y)
+		capped = true
+	}
+	return vpsz, capped
+}
+
+// Result returns the user-set value(s) of the dialog via the ResultFunc set
+// by its constructor -- nil if no ResultFunc was set.  callers assert to the
+// type documented by the constructor (e.g., string for StringPromptDialog)
+func (dlg *Dialog) Result() interface{} {
+	if dlg.ResultFunc == nil {
+		return nil
+	}
+	return dlg.ResultFunc()
+}
+
 // ValidViewport finds a non-nil viewport, either using the provided one, or
 // using the first main window's viewport
 func ValidViewport(avp *Viewport2D) *Viewport2D {
@@ -90,6 +253,10 @@ func ValidViewport(avp *Viewport2D) *Viewport2D {
 // cvgFunc can perform additional configuration after the dialog window has
 // been created and dialog added to it -- some configs require the window.
 func (dlg *Dialog) Open(x, y int, avp *Viewport2D, cfgFunc func()) bool {
+	if dlg.Modal && DialogsQueueModal && ActiveModalDialog() != nil {
+		DialogQueue = append(DialogQueue, &DialogQueueEntry{Dlg: dlg, X: x, Y: y, Vp: avp, CfgFunc: cfgFunc})
+		return true
+	}
 	avp = ValidViewport(avp)
 	if avp == nil {
 		return false
@@ -98,16 +265,19 @@ func (dlg *Dialog) Open(x, y int, avp *Viewport2D, cfgFunc func()) bool {
 	if win == nil {
 		return false
 	}
+	dlg.OpenerWin = win
 
 	updt := dlg.UpdateStart()
 	if dlg.Modal {
 		dlg.State = DialogOpenModal
+		ModalDialogPush(dlg)
 	} else {
 		dlg.State = DialogOpenModeless
 	}
 
-	if DialogsSepWindow {
-		win = NewDialogWin(dlg.Nm, dlg.Title, 100, 100, dlg.Modal)
+	if dlg.IsSepWindow() {
+		szhint := DialogWinSizeHint(win)
+		win = NewDialogWin(dlg.Nm, dlg.Title, szhint.X, szhint.Y, dlg.Modal, dlg.FixedSize)
 		win.AddChild(dlg)
 		win.Viewport = &dlg.Viewport2D
 		win.MasterVLay = dlg.Frame().Embed(KiT_Layout).(*Layout)
@@ -132,7 +302,7 @@ func (dlg *Dialog) Open(x, y int, avp *Viewport2D, cfgFunc func()) bool {
 	vpsz := dlg.DefSize
 
 	if dlg.DefSize == image.ZP {
-		if DialogsSepWindow {
+		if dlg.IsSepWindow() {
 			vpsz = frame.LayData.Size.Pref.ToPoint()
 		} else {
 			vpsz = frame.LayData.Size.Pref.Min(win.Viewport.LayData.AllocSize.MulVal(.9)).ToPoint()
@@ -141,9 +311,25 @@ func (dlg *Dialog) Open(x, y int, avp *Viewport2D, cfgFunc func()) bool {
 
 	stw := int(dlg.Sty.Layout.MinWidth.Dots)
 	sth := int(dlg.Sty.Layout.MinHeight.Dots)
+	if dlg.MinSize.X > 0 { // explicit min overrides the style-derived min
+		stw = 0
+	}
+	if dlg.MinSize.Y > 0 {
+		sth = 0
+	}
 	// fmt.Printf("dlg stw %v sth %v dpi %v vpsz: %v\n", stw, sth, dlg.Sty.UnContext.DPI, vpsz)
 	vpsz.X = ints.MaxInt(vpsz.X, stw)
 	vpsz.Y = ints.MaxInt(vpsz.Y, sth)
+	var capped bool
+	vpsz, capped = dlg.ConstrainSize(vpsz)
+	if capped { // oversized content scrolls within the frame
+		frame.SetProp("overflow", OverflowAuto)
+	}
+	clamped := ClampSizeToScreen(vpsz, win)
+	if clamped != vpsz { // oversized content scrolls within the frame
+		vpsz = clamped
+		frame.SetProp("overflow", OverflowAuto)
+	}
 
 	// note: LowPri allows all other events to be processed before dialog
 	win.ConnectEvent(dlg.This(), oswin.KeyChordEvent, LowPri, func(recv, send ki.Ki, sig int64, d interface{}) {
@@ -168,10 +354,22 @@ func (dlg *Dialog) Open(x, y int, avp *Viewport2D, cfgFunc func()) bool {
 		kf := KeyFun(kt.Chord())
 		switch kf {
 		case KeyFunAccept:
-			ddlg.Accept()
+			ddlg.ActivateDefaultButton()
 			kt.SetProcessed()
 		}
 	})
+	if !dlg.IsSepWindow() && dlg.DismissOnClickOutside {
+		win.ConnectEvent(dlg.This(), oswin.MouseEvent, LowPri, func(recv, send ki.Ki, sig int64, d interface{}) {
+			me := d.(*mouse.Event)
+			ddlg, _ := recv.Embed(KiT_Dialog).(*Dialog)
+			if me.Button == mouse.Left && me.Action == mouse.Release {
+				if ddlg.ShouldDismissOnClick(me.Where) {
+					ddlg.Cancel()
+					me.SetProcessed()
+				}
+			}
+		})
+	}
 	// this is not a good idea
 	// win.ConnectEvent(dlg.This(), oswin.MouseEvent, LowRawPri, func(recv, send ki.Ki, sig int64, d interface{}) {
 	// 	me := d.(*mouse.Event)
@@ -182,7 +380,7 @@ func (dlg *Dialog) Open(x, y int, avp *Viewport2D, cfgFunc func()) bool {
 	// 	}
 	// })
 
-	if DialogsSepWindow {
+	if dlg.IsSepWindow() {
 		dlg.UpdateEndNoSig(updt)
 		// fmt.Printf("setsz: %v\n", vpsz)
 		if !win.HasGeomPrefs() {
@@ -207,26 +405,219 @@ func (dlg *Dialog) Open(x, y int, avp *Viewport2D, cfgFunc func()) bool {
 	return true
 }
 
+// DialogWinSizeHint returns an initial size estimate for a new dialog window
+// before its content has been measured, scaled by the logical DPI of the
+// opener window -- a fixed 100x100 start causes a visible resize flash on
+// high-DPI displays where the final size is much larger
+func DialogWinSizeHint(win *Window) image.Point {
+	dpi := float32(units.PxPerInch)
+	if win != nil {
+		dpi = win.LogicalDPI()
+	}
+	sz := int(100 * dpi / units.PxPerInch)
+	return image.Point{sz, sz}
+}
+
+// ClampSizeToScreen clamps given size to the geometry of the screen that the
+// given window is on (or the primary screen if not available), so that
+// dialogs do not open larger than the monitor when content is big -- returns
+// the size unchanged if no screen info is available (e.g., headless tests)
+func ClampSizeToScreen(sz image.Point, win *Window) image.Point {
+	var scrn *oswin.Screen
+	if win != nil && win.OSWin != nil {
+		scrn = win.OSWin.Screen()
+	} else if oswin.TheApp != nil && oswin.TheApp.NScreens() > 0 {
+		scrn = oswin.TheApp.Screen(0)
+	}
+	if scrn == nil {
+		return sz
+	}
+	scsz := scrn.Geometry.Size()
+	sz.X = ints.MinInt(sz.X, scsz.X)
+	sz.Y = ints.MinInt(sz.Y, scsz.Y)
+	return sz
+}
+
+// Build performs the dialog tree construction and styling steps that Open
+// normally does (Init2DTree / Style2DTree) without opening a window or
+// starting an event loop -- for headless use and testing, where the widget
+// tree can then be inspected and Accept / Cancel invoked directly.  The
+// optional viewport provides styling context (e.g., window) if available.
+func (dlg *Dialog) Build(avp *Viewport2D) {
+	if avp != nil {
+		dlg.Win = avp.Win
+	}
+	dlg.Init2DTree()
+	dlg.Style2DTree()
+	dlg.Win = nil
+}
+
+// PositionCenteredOver returns the top-left position for a dialog of given
+// size centered over given widget bounds (in window coordinates), clamped so
+// the dialog remains within a window of given size
+func PositionCenteredOver(dlgsz image.Point, over image.Rectangle, winsz image.Point) image.Point {
+	ctr := over.Min.Add(over.Size().Div(2))
+	pos := ctr.Sub(dlgsz.Div(2))
+	pos.X = ints.MaxInt(0, ints.MinInt(pos.X, winsz.X-dlgsz.X))
+	pos.Y = ints.MaxInt(0, ints.MinInt(pos.Y, winsz.Y-dlgsz.Y))
+	return pos
+}
+
+// PreferredSize computes and returns the preferred pixel size of the dialog
+// content, running the same init / style / size passes that Open uses,
+// without opening the dialog -- callers can assign the result to DefSize to
+// skip the expensive size computation when opening a complex dialog.  The
+// window is optional, to contextualize styling and provide the initial
+// allocation -- without it sizes reflect intrinsic content only.
+func (dlg *Dialog) PreferredSize(win *Window) image.Point {
+	if win != nil {
+		dlg.Win = win
+	}
+	dlg.Init2DTree()
+	dlg.Style2DTree()
+	if win != nil && win.Viewport != nil {
+		dlg.LayData.AllocSize = win.Viewport.LayData.AllocSize
+	}
+	dlg.Size2DTree(0)
+	dlg.Win = nil
+	frame := dlg.Frame()
+	vpsz := frame.LayData.Size.Pref.ToPoint()
+	stw := int(dlg.Sty.Layout.MinWidth.Dots)
+	sth := int(dlg.Sty.Layout.MinHeight.Dots)
+	vpsz.X = ints.MaxInt(vpsz.X, stw)
+	vpsz.Y = ints.MaxInt(vpsz.Y, sth)
+	return vpsz
+}
+
+// OpenOver opens the dialog centered over the given widget (e.g., for inline
+// edit popups), clamping to stay within the window -- centering uses DefSize
+// if set, and otherwise the widget's center is used as the dialog position.
+// Other args are as in Open.
+func (dlg *Dialog) OpenOver(node Node2D, avp *Viewport2D, cfgFunc func()) bool {
+	if node == nil || node.AsNode2D() == nil {
+		return dlg.Open(0, 0, avp, cfgFunc)
+	}
+	nb := node.AsNode2D()
+	avp = ValidViewport(avp)
+	if avp == nil {
+		return false
+	}
+	var pos image.Point
+	if dlg.DefSize != image.ZP {
+		winsz := avp.Geom.Size
+		if avp.Win != nil && avp.Win.Viewport != nil {
+			winsz = avp.Win.Viewport.Geom.Size
+		}
+		pos = PositionCenteredOver(dlg.DefSize, nb.WinBBox, winsz)
+	} else {
+		pos = nb.WinBBox.Min.Add(nb.WinBBox.Size().Div(2))
+	}
+	return dlg.Open(pos.X, pos.Y, avp, cfgFunc)
+}
+
+// CanReopen returns true if this dialog can be reopened via Reopen: it must
+// not be currently open, destroyed, or marked with VpFlagPopupDestroyAll
+// (which destroys all children on close, as the std dialogs do)
+func (dlg *Dialog) CanReopen() bool {
+	if dlg == nil || dlg.This() == nil || dlg.IsDestroyed() || dlg.IsDeleted() {
+		return false
+	}
+	if dlg.HasFlag(int(VpFlagPopupDestroyAll)) {
+		return false
+	}
+	if dlg.State == DialogOpenModal || dlg.State == DialogOpenModeless {
+		return false
+	}
+	return true
+}
+
+// Reopen reopens an already-constructed dialog that was previously closed,
+// without rebuilding its children -- much faster for complex dialogs (see
+// also DefSize).  Returns false if the dialog cannot be reused (see
+// CanReopen).  Open reconnects the key handlers -- event connections are not
+// duplicated for an already-connected receiver.
+func (dlg *Dialog) Reopen(x, y int, avp *Viewport2D) bool {
+	if !dlg.CanReopen() {
+		return false
+	}
+	return dlg.Open(x, y, avp, nil)
+}
+
+// Reconfigure updates the title and prompt text of an already-constructed
+// dialog in place, without rebuilding its children, and triggers a relayout
+// -- for repurposing a cached / reusable dialog before reopening it (see
+// Reopen).  The window title is updated too for separate-window dialogs.
+func (dlg *Dialog) Reconfigure(title, prompt string) {
+	if dlg == nil || dlg.This() == nil || dlg.IsDestroyed() || dlg.IsDeleted() {
+		return
+	}
+	updt := dlg.UpdateStart()
+	dlg.Title = title
+	dlg.Prompt = prompt
+	frame := dlg.Frame()
+	if frame != nil {
+		if lbl, _ := dlg.TitleWidget(frame); lbl != nil {
+			lbl.Text = title
+		}
+		if idx, ok := frame.Children().IndexByName("prompt", 0); ok {
+			frame.KnownChild(idx).(*Label).Text = prompt
+		}
+	}
+	if dlg.IsSepWindow() && dlg.Win != nil {
+		dlg.Win.SetTitle(title)
+	}
+	dlg.SetFullReRender()
+	dlg.UpdateEnd(updt)
+}
+
+// ShouldDismissOnClick returns true if a click at given window point should
+// dismiss the dialog: only for modeless dialogs with DismissOnClickOutside
+// set, when the point is outside of the dialog bounds
+func (dlg *Dialog) ShouldDismissOnClick(pt image.Point) bool {
+	if !dlg.DismissOnClickOutside || dlg.Modal {
+		return false
+	}
+	return !pt.In(dlg.Geom.Bounds())
+}
+
 // Close requests that the dialog be closed -- it does not alter any state or send any signals
 func (dlg *Dialog) Close() {
 	if dlg == nil || dlg.This() == nil || dlg.IsDestroyed() || dlg.IsDeleted() {
 		return
 	}
+	if dlg.Modal {
+		ModalDialogPop(dlg)
+	}
 	win := dlg.Win
 	if win != nil {
-		if DialogsSepWindow {
+		if dlg.IsSepWindow() {
 			win.Close()
+			opener := dlg.OpenerWin
+			if opener != nil && !opener.IsClosed() && opener.OSWin != nil {
+				opener.OSWin.Raise() // return focus to the opener, if still around
+			}
 		} else {
 			win.ClosePopup(dlg.This())
 		}
 	}
 }
 
-// Accept accepts the dialog, activated by the default Ok button
+// Accept accepts the dialog, activated by the default Ok button -- if an
+// AcceptCheck function is set and returns an error, the error is shown and
+// the dialog remains open
 func (dlg *Dialog) Accept() {
 	if dlg == nil {
 		return
 	}
+	if dlg.State == DialogAccepted || dlg.State == DialogCanceled {
+		return // already resolved (e.g., double-click on Ok) -- emit only once
+	}
+	if dlg.AcceptCheck != nil {
+		if err := dlg.AcceptCheck(); err != nil {
+			PromptDialog(nil, DlgOpts{Title: "Invalid Input", Prompt: err.Error()}, true, false, nil, nil)
+			return
+		}
+	}
 	dlg.State = DialogAccepted
 	if dlg.SigVal >= 0 {
 		dlg.DialogSig.Emit(dlg.This(), dlg.SigVal, nil)
@@ -241,6 +632,9 @@ func (dlg *Dialog) Cancel() {
 	if dlg == nil {
 		return
 	}
+	if dlg.State == DialogAccepted || dlg.State == DialogCanceled {
+		return // already resolved -- emit only once
+	}
 	dlg.State = DialogCanceled
 	if dlg.SigVal >= 0 {
 		dlg.DialogSig.Emit(dlg.This(), dlg.SigVal, nil)
@@ -296,6 +690,17 @@ func (dlg *Dialog) Frame() *Frame {
 	return dlg.KnownChild(0).(*Frame)
 }
 
+// SetMaxContentHeight caps the height of the dialog content frame at given
+// value -- content taller than this scrolls (overflow auto) rather than
+// growing the dialog, regardless of screen size
+func (dlg *Dialog) SetMaxContentHeight(mh units.Value, frame *Frame) {
+	dlg.MaxContentHeight = mh
+	if frame != nil {
+		frame.SetProp("max-height", mh)
+		frame.SetProp("overflow", OverflowAuto)
+	}
+}
+
 // SetTitle sets the title and adds a Label named "title" to the given frame layout if passed
 func (dlg *Dialog) SetTitle(title string, frame *Frame) *Label {
 	dlg.Title = title
@@ -340,6 +745,63 @@ func (dlg *Dialog) PromptWidget(frame *Frame) (*Label, int) {
 	return frame.KnownChild(idx).(*Label), idx
 }
 
+// SetDetails sets the details text and adds a "Details" toggle to the given
+// frame layout if passed -- the details text itself is only added to the
+// dialog when the toggle is expanded, so a collapsed dialog stays compact
+func (dlg *Dialog) SetDetails(details string, frame *Frame) *CheckBox {
+	dlg.Details = details
+	if frame == nil {
+		return nil
+	}
+	_, prIdx := dlg.PromptWidget(frame)
+	tgl := frame.InsertNewChild(KiT_CheckBox, prIdx+1, "details-toggle").(*CheckBox)
+	tgl.Text = "Details" // parts are configured on init
+
+	tgl.ButtonSig.Connect(dlg.This(), func(recv, send ki.Ki, sig int64, data interface{}) {
+		if sig == int64(ButtonToggled) {
+			ddlg := recv.Embed(KiT_Dialog).(*Dialog)
+			tb := send.Embed(KiT_CheckBox).(*CheckBox)
+			ddlg.ToggleDetails(tb.IsChecked())
+		}
+	})
+	return tgl
+}
+
+// DetailsWidget returns the details text label widget, and its index, within
+// frame -- nil, -1 if not present (collapsed, or no details set)
+func (dlg *Dialog) DetailsWidget(frame *Frame) (*Label, int) {
+	idx, ok := frame.Children().IndexByName("details-text", 0)
+	if !ok {
+		return nil, -1
+	}
+	return frame.KnownChild(idx).(*Label), idx
+}
+
+// ToggleDetails expands or collapses the details pane, adding or removing
+// the scrollable details text below the Details toggle
+func (dlg *Dialog) ToggleDetails(show bool) {
+	frame := dlg.Frame()
+	lbl, idx := dlg.DetailsWidget(frame)
+	updt := frame.UpdateStart()
+	if show && lbl == nil {
+		tglIdx, ok := frame.Children().IndexByName("details-toggle", 0)
+		if !ok {
+			frame.UpdateEnd(updt)
+			return
+		}
+		lbl = frame.InsertNewChild(KiT_Label, tglIdx+1, "details-text").(*Label)
+		lbl.Text = dlg.Details
+		lbl.SetProp("white-space", WhiteSpaceNormal)
+		lbl.SetProp("max-width", -1)
+		lbl.SetProp("max-height", units.NewValue(10, units.Em)) // scrolls beyond this
+		dlg.StylePart(Node2D(lbl))
+	} else if !show && lbl != nil {
+		frame.DeleteChildAtIndex(idx, true)
+	}
+	dlg.SetFullReRender()
+	frame.UpdateEnd(updt)
+}
+
 // AddButtonBox adds a button box (Row Layout) named "buttons" to given frame,
 // with an extra space above it
 func (dlg *Dialog) AddButtonBox(frame *Frame) *Layout {
@@ -384,6 +846,140 @@ func (dlg *Dialog) StdButtonConfig(stretch, ok, cancel bool) kit.TypeAndNameList
 	return config
 }
 
+// SetDefaultButton marks the button at given index within given button box
+// as the default (recommended) choice -- it is displayed selected and grabs
+// keyboard focus if the dialog is in a window.  -1 clears any existing
+// default.  Returns the default button, or nil.
+func (dlg *Dialog) SetDefaultButton(bb *Layout, defIdx int) *Button {
+	if bb == nil {
+		return nil
+	}
+	var def *Button
+	bidx := 0
+	for _, bbk := range *bb.Children() {
+		bbb := bbk.Embed(KiT_Button)
+		if bbb == nil { // spaces, stretches
+			continue
+		}
+		b := bbb.(*Button)
+		if bidx == defIdx {
+			def = b
+			b.SetSelectedState(true)
+		} else {
+			b.SetSelectedState(false)
+		}
+		bidx++
+	}
+	if def != nil {
+		def.GrabFocus() // no-op if not in a window yet
+	}
+	return def
+}
+
+// SetDefaultButtonName designates the named button in the button box as the
+// dialog's default action, activated by KeyFunAccept (Enter) from anywhere
+// in the dialog (see ActivateDefaultButton) -- also shows it selected, via
+// SetDefaultButton.  An empty name clears the designation, restoring the
+// plain Accept behavior.
+func (dlg *Dialog) SetDefaultButtonName(name string) *Button {
+	dlg.DefButton = name
+	frame := dlg.Frame()
+	if frame == nil {
+		return nil
+	}
+	bb, _ := dlg.ButtonBox(frame)
+	if bb == nil {
+		return nil
+	}
+	if name == "" {
+		return dlg.SetDefaultButton(bb, -1)
+	}
+	bidx := 0
+	for _, bbk := range *bb.Children() {
+		bbb := bbk.Embed(KiT_Button)
+		if bbb == nil {
+			continue
+		}
+		if bbb.(*Button).Nm == name {
+			return dlg.SetDefaultButton(bb, bidx)
+		}
+		bidx++
+	}
+	return nil
+}
+
+// ButtonByName returns the named button within the dialog's button box --
+// nil if no frame, button box, or button of that name
+func (dlg *Dialog) ButtonByName(name string) *Button {
+	frame := dlg.Frame()
+	if frame == nil {
+		return nil
+	}
+	bb, _ := dlg.ButtonBox(frame)
+	if bb == nil {
+		return nil
+	}
+	bi, ok := bb.Children().IndexByName(name, 0)
+	if !ok {
+		return nil
+	}
+	bbb := bb.KnownChild(bi).Embed(KiT_Button)
+	if bbb == nil {
+		return nil
+	}
+	return bbb.(*Button)
+}
+
+// SetButtonText sets the label of the named button in the button box
+// (e.g., "ok", "cancel") -- use to replace the generic Ok / Cancel labels
+// with action-specific ones like "Delete" / "Keep".  Returns the button,
+// or nil if not found.
+func (dlg *Dialog) SetButtonText(name, text string) *Button {
+	but := dlg.ButtonByName(name)
+	if but == nil {
+		return nil
+	}
+	but.SetText(text)
+	return but
+}
+
+// SetDestructiveButton styles the named button in the button box as a
+// destructive action (red background, white text) -- use on the confirm
+// button of dialogs for irreversible operations such as delete.  Returns
+// the button, or nil if not found.
+func (dlg *Dialog) SetDestructiveButton(name string) *Button {
+	but := dlg.ButtonByName(name)
+	if but == nil {
+		return nil
+	}
+	but.SetProp("background-color", "#C83232")
+	but.SetProp("color", "white")
+	return but
+}
+
+// ActivateDefaultButton activates the designated default button (see
+// SetDefaultButtonName) by emitting its click, returning true if so -- when
+// no default is designated or found, it falls back on Accept and returns
+// false.  Called by the KeyFunAccept (Enter) key handler, which runs at low
+// priority, so focused widgets that consume Enter (e.g., multiline text
+// fields) never let the event reach it.
+func (dlg *Dialog) ActivateDefaultButton() bool {
+	if dlg.DefButton != "" {
+		frame := dlg.Frame()
+		if frame != nil {
+			if bb, _ := dlg.ButtonBox(frame); bb != nil {
+				if bi, ok := bb.Children().IndexByName(dlg.DefButton, 0); ok {
+					but := bb.KnownChild(bi).Embed(KiT_Button).(*Button)
+					but.ButtonSig.Emit(but.This(), int64(ButtonClicked), nil)
+					return true
+				}
+			}
+		}
+	}
+	dlg.Accept()
+	return false
+}
+
 // StdButtonConnect connects standard buttons in given button box layout to
 // Accept / Cancel actions
 func (dlg *Dialog) StdButtonConnect(ok, cancel bool, bb *Layout) {
@@ -433,9 +1029,35 @@ func (dlg *Dialog) StdDialog(title, prompt string, ok, cancel bool) {
 // DlgOpts are the basic dialog options accepted by all dialog methods --
 // provides a named, optional way to specify these args
 type DlgOpts struct {
-	Title  string   `desc:"generally should be provided -- will also be used for setting name of dialog and associated window"`
-	Prompt string   `desc:"optional more detailed description of what is being requested and how it will be used -- is word-wrapped and can contain full html formatting etc."`
-	CSS    ki.Props `desc:"optional style properties applied to dialog -- can be used to customize any aspect of existing dialogs"`
+	Title                 string      `desc:"generally should be provided -- will also be used for setting name of dialog and associated window"`
+	Name                  string      `desc:"optional explicit name for the dialog and associated window -- overrides the kebab-case name derived from Title, for stable window tracking and remembered geometry when titles vary or collide"`
+	Prompt                string      `desc:"optional more detailed description of what is being requested and how it will be used -- is word-wrapped and can contain full html formatting etc."`
+	CSS                   ki.Props    `desc:"optional style properties applied to dialog -- can be used to customize any aspect of existing dialogs"`
+	DismissOnClickOutside bool        `desc:"for modeless in-window dialogs, dismiss (Cancel) the dialog when a click occurs outside of its bounds"`
+	Details               string      `desc:"optional details text shown in a collapsible Details pane below the prompt -- for stack traces, full error messages etc"`
+	MaxContentHeight      units.Value `desc:"optional maximum height for the dialog content frame -- if non-zero, taller content scrolls instead of growing the dialog"`
+	FixedSize             bool        `desc:"for separate-window dialogs, open the window at the computed size and prevent the user from resizing it -- dialogs are resizable by default"`
+	OkText                string      `desc:"optional label for the Ok button, replacing the generic Ok -- e.g., Delete for a destructive confirm"`
+	CancelText            string      `desc:"optional label for the Cancel button, replacing the generic Cancel"`
+	Destructive           bool        `desc:"style the Ok button as a destructive action (see SetDestructiveButton) -- for confirming irreversible operations"`
+}
+
+// UniqueDialogName returns a dialog / window name based on given base name
+// (typically the kebab-case title), appending an incrementing numeric suffix
+// while a window of that name already exists -- two same-titled dialogs would
+// otherwise get the same name, breaking window tracking and the
+// remembered-geometry features.  Use DlgOpts.Name for an explicit stable name
+// instead.
+func UniqueDialogName(base string) string {
+	if _, has := AllWindows.FindName(base); !has {
+		return base
+	}
+	for i := 2; ; i++ {
+		nm := fmt.Sprintf("%v-%v", base, i)
+		if _, has := AllWindows.FindName(nm); !has {
+			return nm
+		}
+	}
 }
 
 // NewStdDialog returns a basic standard dialog with given options (title,
@@ -444,15 +1066,36 @@ type DlgOpts struct {
 // ended -- must call UpdateEnd(true) once done configuring!
 func NewStdDialog(opts DlgOpts, ok, cancel bool) *Dialog {
 	title := opts.Title
-	nm := strcase.ToKebab(title)
-	if title == "" {
-		nm = "unnamed-dialog"
+	nm := opts.Name
+	if nm == "" {
+		nm = strcase.ToKebab(title)
+		if title == "" {
+			nm = "unnamed-dialog"
+		}
+		nm = UniqueDialogName(nm)
 	}
 	dlg := Dialog{}
 	dlg.InitName(&dlg, nm)
 	dlg.UpdateStart() // guaranteed to be true
 	dlg.CSS = opts.CSS
+	dlg.DismissOnClickOutside = opts.DismissOnClickOutside
+	dlg.FixedSize = opts.FixedSize
 	dlg.StdDialog(opts.Title, opts.Prompt, ok, cancel)
+	if opts.OkText != "" {
+		dlg.SetButtonText("ok", opts.OkText)
+	}
+	if opts.CancelText != "" {
+		dlg.SetButtonText("cancel", opts.CancelText)
+	}
+	if opts.Destructive {
+		dlg.SetDestructiveButton("ok")
+	}
+	if opts.Details != "" {
+		dlg.SetDetails(opts.Details, dlg.Frame())
+	}
+	if opts.MaxContentHeight.Val > 0 {
+		dlg.SetMaxContentHeight(opts.MaxContentHeight, dlg.Frame())
+	}
 	return &dlg
 }
 
@@ -488,8 +1131,10 @@ func PromptDialog(avp *Viewport2D, opts DlgOpts, ok, cancel bool, recv ki.Ki, fu
 // ChoiceDialog presents any number of buttons with labels as given, for the
 // user to choose among -- the clicked button number (starting at 0) will be
 // sent to the receiving object and function for dialog signals.  Viewport is
-// optional to properly contextualize dialog to given master window.
-func ChoiceDialog(avp *Viewport2D, opts DlgOpts, choices []string, recv ki.Ki, fun ki.RecvFunc) {
+// optional to properly contextualize dialog to given master window.  An
+// optional final defIdx arg marks that choice as the default (recommended)
+// one, emphasized and initially focused -- -1 or omitted means no default.
+func ChoiceDialog(avp *Viewport2D, opts DlgOpts, choices []string, recv ki.Ki, fun ki.RecvFunc, defIdx ...int) {
 	dlg := NewStdDialog(opts, false, false) // no buttons
 	dlg.Modal = true
 	if recv != nil && fun != nil {
@@ -523,9 +1168,109 @@ func ChoiceDialog(avp *Viewport2D, opts DlgOpts, choices []string, recv ki.Ki, f
 			})
 		}
 	}
+	if len(defIdx) > 0 && defIdx[0] >= 0 {
+		dlg.SetDefaultButton(bb, defIdx[0])
+	}
+
+	dlg.UpdateEndNoSig(true) // going to be shown
+	dlg.Open(0, 0, avp, nil)
+}
+
+// ChoiceDialogCB is the callback version of ChoiceDialog: it presents any
+// number of buttons with labels as given, for the user to choose among, and
+// calls fun with the chosen index on accept, and -1 on cancel (a "Cancel"
+// labeled choice, or the Escape key) -- avoids the SigVal / Embed
+// boilerplate of connecting to DialogSig.  Returns the dialog.
+func ChoiceDialogCB(avp *Viewport2D, opts DlgOpts, choices []string, fun func(idx int)) *Dialog {
+	dlg := NewStdDialog(opts, false, false) // no buttons
+	dlg.Modal = true
+
+	frame := dlg.Frame()
+	bb, _ := dlg.ButtonBox(frame)
+	for i, ch := range choices {
+		chnm := strcase.ToKebab(ch)
+		b := bb.AddNewChild(KiT_Button, chnm).(*Button)
+		b.SetProp("__cdSigVal", int64(i))
+		b.SetText(ch)
+		if chnm == "cancel" {
+			b.ButtonSig.Connect(dlg.This(), func(recv, send ki.Ki, sig int64, data interface{}) {
+				if sig == int64(ButtonClicked) {
+					dlg := recv.Embed(KiT_Dialog).(*Dialog)
+					dlg.Cancel() // SigVal stays -1 -- reported as cancel
+				}
+			})
+		} else {
+			b.ButtonSig.Connect(dlg.This(), func(recv, send ki.Ki, sig int64, data interface{}) {
+				if sig == int64(ButtonClicked) {
+					tb := send.Embed(KiT_Button).(*Button)
+					dlg := recv.Embed(KiT_Dialog).(*Dialog)
+					dlg.SigVal = tb.KnownProp("__cdSigVal").(int64)
+					dlg.Accept()
+				}
+			})
+		}
+	}
+	if fun != nil {
+		dlg.DialogSig.Connect(dlg.This(), func(recv, send ki.Ki, sig int64, data interface{}) {
+			ddlg := recv.Embed(KiT_Dialog).(*Dialog)
+			if ddlg.SigVal >= 0 {
+				fun(int(sig))
+			} else {
+				fun(-1)
+			}
+		})
+	}
+
+	dlg.UpdateEndNoSig(true) // going to be shown
+	dlg.Open(0, 0, avp, nil)
+	return dlg
+}
+
+// YesNoCancelDialog presents the standard Yes / No / Cancel three-choice
+// dialog -- fun is called with the chosen index: 0 = Yes, 1 = No, 2 = Cancel.
+// The Escape / abort key also selects Cancel.  Viewport is optional to
+// properly contextualize dialog to given master window.
+func YesNoCancelDialog(avp *Viewport2D, opts DlgOpts, fun func(choice int)) *Dialog {
+	dlg := NewStdDialog(opts, false, false) // no buttons
+	dlg.Modal = true
+	dlg.SigVal = 2 // cancel paths without a button click (Escape) report Cancel
+
+	frame := dlg.Frame()
+	bb, _ := dlg.ButtonBox(frame)
+	for i, ch := range []string{"Yes", "No", "Cancel"} {
+		chnm := strcase.ToKebab(ch)
+		b := bb.AddNewChild(KiT_Button, chnm).(*Button)
+		b.SetProp("__cdSigVal", int64(i))
+		b.SetText(ch)
+		if chnm == "cancel" {
+			b.ButtonSig.Connect(dlg.This(), func(recv, send ki.Ki, sig int64, data interface{}) {
+				if sig == int64(ButtonClicked) {
+					tb := send.Embed(KiT_Button).(*Button)
+					dlg := recv.Embed(KiT_Dialog).(*Dialog)
+					dlg.SigVal = tb.KnownProp("__cdSigVal").(int64)
+					dlg.Cancel()
+				}
+			})
+		} else {
+			b.ButtonSig.Connect(dlg.This(), func(recv, send ki.Ki, sig int64, data interface{}) {
+				if sig == int64(ButtonClicked) {
+					tb := send.Embed(KiT_Button).(*Button)
+					dlg := recv.Embed(KiT_Dialog).(*Dialog)
+					dlg.SigVal = tb.KnownProp("__cdSigVal").(int64)
+					dlg.Accept()
+				}
+			})
+		}
+	}
+	if fun != nil {
+		dlg.DialogSig.Connect(dlg.This(), func(recv, send ki.Ki, sig int64, data interface{}) {
+			fun(int(sig))
+		})
+	}
 
 	dlg.UpdateEndNoSig(true) // going to be shown
 	dlg.Open(0, 0, avp, nil)
+	return dlg
 }
 
 // NewKiDialog prompts for creating new item(s) of a given type, showing types
@@ -564,6 +1309,10 @@ func NewKiDialog(avp *Viewport2D, iface reflect.Type, opts DlgOpts, recv ki.Ki,
 	typs := trow.AddNewChild(KiT_ComboBox, "types").(*ComboBox)
 	typs.ItemsFromTypes(kit.Types.AllImplementersOf(iface, false), true, true, 50)
 
+	dlg.ResultFunc = func() interface{} {
+		n, typ := NewKiDialogValues(dlg)
+		return NewKiDialogResult{N: n, Type: typ}
+	}
 	if recv != nil && fun != nil {
 		dlg.DialogSig.Connect(recv, fun)
 	}
@@ -572,6 +1321,13 @@ func NewKiDialog(avp *Viewport2D, iface reflect.Type, opts DlgOpts, recv ki.Ki,
 	return dlg
 }
 
+// NewKiDialogResult is the typed result of a NewKiDialog, returned by
+// dlg.Result()
+type NewKiDialogResult struct {
+	N    int          `desc:"number of items to create"`
+	Type reflect.Type `desc:"type of item to create"`
+}
+
 // NewKiDialogValues gets the user-set values from a NewKiDialog.
 func NewKiDialogValues(dlg *Dialog) (int, reflect.Type) {
 	frame := dlg.Frame()
@@ -605,6 +1361,9 @@ func StringPromptDialog(avp *Viewport2D, strval, placeholder string, opts DlgOpt
 	tf.SetStretchMaxWidth()
 	tf.SetMinPrefWidth(units.NewValue(40, units.Ch))
 
+	dlg.ResultFunc = func() interface{} {
+		return StringPromptDialogValue(dlg)
+	}
 	if recv != nil && fun != nil {
 		dlg.DialogSig.Connect(recv, fun)
 	}
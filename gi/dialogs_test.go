@@ -0,0 +1,829 @@
+// Copyright (c) 2019, The GoKi Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package gi
+
+import (
+	"image"
+	"testing"
+
+	"github.com/goki/gi/oswin"
+	"github.com/goki/gi/units"
+	"github.com/goki/ki"
+	"github.com/goki/ki/bitflag"
+)
+
+func newTestDialog(name string) *Dialog {
+	dlg := &Dialog{}
+	dlg.InitName(dlg, name)
+	dlg.Modal = true
+	return dlg
+}
+
+func TestModalDialogStack(t *testing.T) {
+	ModalDialogStack = nil
+	d1 := newTestDialog("outer")
+	d2 := newTestDialog("middle")
+	d3 := newTestDialog("inner")
+
+	ModalDialogPush(d1)
+	ModalDialogPush(d2)
+	ModalDialogPush(d3)
+	if ActiveModalDialog() != d3 {
+		t.Errorf("active modal should be inner, got %v", ActiveModalDialog())
+	}
+
+	// normal nested close order: top first
+	ModalDialogPop(d3)
+	if ActiveModalDialog() != d2 {
+		t.Errorf("after popping inner, active modal should be middle")
+	}
+
+	// out-of-order: close the bottom dialog before the top one
+	ModalDialogPop(d1)
+	if ActiveModalDialog() != d2 {
+		t.Errorf("popping outer should not change the active modal")
+	}
+	ModalDialogPop(d2)
+	if ActiveModalDialog() != nil {
+		t.Errorf("stack should be empty, got %v", ActiveModalDialog())
+	}
+
+	// popping a dialog not on the stack is a no-op
+	ModalDialogPop(d1)
+	if len(ModalDialogStack) != 0 {
+		t.Errorf("pop of unlisted dialog should leave stack empty")
+	}
+}
+
+func TestPositionCenteredOver(t *testing.T) {
+	winsz := image.Point{800, 600}
+	widg := image.Rect(300, 200, 400, 240) // center (350, 220)
+	dlgsz := image.Point{200, 100}
+	pos := PositionCenteredOver(dlgsz, widg, winsz)
+	if pos != (image.Point{250, 170}) {
+		t.Errorf("expected centered pos (250, 170), got %v", pos)
+	}
+	// clamped to stay on-screen for a widget near the origin
+	widg = image.Rect(0, 0, 20, 20)
+	pos = PositionCenteredOver(dlgsz, widg, winsz)
+	if pos != (image.Point{0, 0}) {
+		t.Errorf("expected clamped pos (0, 0), got %v", pos)
+	}
+}
+
+func TestDialogDetailsToggle(t *testing.T) {
+	dlg := NewStdDialog(DlgOpts{Title: "Error", Prompt: "something failed",
+		Details: "full stack trace here"}, true, false)
+	dlg.UpdateEndNoSig(true)
+	frame := dlg.Frame()
+	if _, ok := frame.Children().IndexByName("details-toggle", 0); !ok {
+		t.Fatalf("details toggle should be present when Details is set")
+	}
+	if lbl, _ := dlg.DetailsWidget(frame); lbl != nil {
+		t.Errorf("details text should be collapsed by default")
+	}
+	dlg.ToggleDetails(true)
+	lbl, _ := dlg.DetailsWidget(frame)
+	if lbl == nil || lbl.Text != "full stack trace here" {
+		t.Fatalf("details text should be shown after expanding: %v", lbl)
+	}
+	dlg.ToggleDetails(false)
+	if lbl, _ := dlg.DetailsWidget(frame); lbl != nil {
+		t.Errorf("details text should be removed after collapsing")
+	}
+}
+
+func TestDialogMaxContentHeight(t *testing.T) {
+	mh := units.NewValue(20, units.Em)
+	dlg := NewStdDialog(DlgOpts{Title: "Log", Prompt: "long output",
+		MaxContentHeight: mh}, true, false)
+	dlg.UpdateEndNoSig(true)
+	frame := dlg.Frame()
+	if dlg.MaxContentHeight != mh {
+		t.Errorf("MaxContentHeight not set on dialog: %v", dlg.MaxContentHeight)
+	}
+	if mhp, ok := frame.Prop("max-height"); !ok || mhp != mh {
+		t.Errorf("frame max-height prop should be %v, got %v", mh, mhp)
+	}
+	if ofp, ok := frame.Prop("overflow"); !ok || ofp != OverflowAuto {
+		t.Errorf("frame overflow prop should engage scrolling, got %v", ofp)
+	}
+}
+
+func TestDialogSetDefaultButton(t *testing.T) {
+	dlg := NewStdDialog(DlgOpts{Title: "Save?", Prompt: "save changes?"}, true, true)
+	dlg.UpdateEndNoSig(true)
+	frame := dlg.Frame()
+	bb, _ := dlg.ButtonBox(frame)
+	def := dlg.SetDefaultButton(bb, 1)
+	if def == nil || def.Nm != "cancel" {
+		t.Fatalf("default button at index 1 should be cancel, got %v", def)
+	}
+	if !def.IsSelected() {
+		t.Errorf("default button should be shown selected")
+	}
+	// moving the default clears the emphasis on the old one
+	def2 := dlg.SetDefaultButton(bb, 0)
+	if def2 == nil || def2.Nm != "ok" || !def2.IsSelected() {
+		t.Errorf("default button at index 0 should be ok and selected, got %v", def2)
+	}
+	if def.IsSelected() {
+		t.Errorf("previous default should no longer be selected")
+	}
+	if dlg.SetDefaultButton(bb, -1) != nil {
+		t.Errorf("SetDefaultButton(-1) should clear and return nil")
+	}
+	if def2.IsSelected() {
+		t.Errorf("clearing default should deselect all buttons")
+	}
+}
+
+func TestDialogReconfigure(t *testing.T) {
+	dlg := NewStdDialog(DlgOpts{Title: "First", Prompt: "original text"}, true, true)
+	dlg.UpdateEndNoSig(true)
+	dlg.Reconfigure("Second", "updated text")
+	if dlg.Title != "Second" || dlg.Prompt != "updated text" {
+		t.Errorf("dialog fields not updated: %v, %v", dlg.Title, dlg.Prompt)
+	}
+	frame := dlg.Frame()
+	plbl, _ := dlg.PromptWidget(frame)
+	if plbl == nil || plbl.Text != "updated text" {
+		t.Errorf("prompt label should be updated in place: %v", plbl)
+	}
+	// custom dialogs can have an in-frame title label -- updated in place too
+	dlg = newTestDialog("custom")
+	frame = dlg.SetFrame()
+	dlg.SetTitle("First", frame)
+	dlg.SetPrompt("original text", frame)
+	dlg.Reconfigure("Renamed", "new prompt")
+	tlbl, _ := dlg.TitleWidget(frame)
+	if tlbl == nil || tlbl.Text != "Renamed" {
+		t.Errorf("title label should be updated in place: %v", tlbl)
+	}
+	plbl, _ = dlg.PromptWidget(frame)
+	if plbl == nil || plbl.Text != "new prompt" {
+		t.Errorf("custom prompt label should be updated in place: %v", plbl)
+	}
+	// title-only dialog: new prompt must not clobber the title label
+	dlg = newTestDialog("title-only")
+	frame = dlg.SetFrame()
+	dlg.SetTitle("OnlyTitle", frame)
+	dlg.Reconfigure("StillTitle", "ignored prompt")
+	tlbl, _ = dlg.TitleWidget(frame)
+	if tlbl == nil || tlbl.Text != "StillTitle" {
+		t.Errorf("title-only dialog: title label should be %q, got %v", "StillTitle", tlbl)
+	}
+}
+
+func TestDialogSepWindowOverride(t *testing.T) {
+	svSep := DialogsSepWindow
+	defer func() { DialogsSepWindow = svSep }()
+
+	// one dialog each way under a single global setting
+	DialogsSepWindow = true
+	inl := newTestDialog("inline")
+	inl.SetSepWindow(false)
+	sep := newTestDialog("sepwin")
+	if inl.IsSepWindow() {
+		t.Errorf("dialog with SepWindow=false should be inline despite global")
+	}
+	if !sep.IsSepWindow() {
+		t.Errorf("dialog without override should follow global (separate)")
+	}
+
+	// flipping the global mid-flight must not change an overridden dialog,
+	// so Accept/Cancel/Close branch the same way Open did
+	DialogsSepWindow = false
+	if inl.IsSepWindow() {
+		t.Errorf("override should be stable across global changes")
+	}
+	if sep.IsSepWindow() {
+		t.Errorf("non-overridden dialog should follow global (inline)")
+	}
+	sep.SetSepWindow(true)
+	if !sep.IsSepWindow() {
+		t.Errorf("dialog with SepWindow=true should be separate despite global")
+	}
+}
+
+func TestDialogFixedSize(t *testing.T) {
+	dlg := NewStdDialog(DlgOpts{Title: "About", FixedSize: true}, true, false)
+	dlg.UpdateEndNoSig(true)
+	if !dlg.FixedSize {
+		t.Errorf("FixedSize opt should be set on dialog")
+	}
+	// default is resizable
+	dlg = NewStdDialog(DlgOpts{Title: "About"}, true, false)
+	dlg.UpdateEndNoSig(true)
+	if dlg.FixedSize {
+		t.Errorf("dialogs should be resizable by default")
+	}
+
+	// the window options resize hint matches the flag
+	opts := &oswin.NewWindowOptions{}
+	opts.SetDialog()
+	if bitflag.Has(opts.Flags, int(oswin.FixedSize)) {
+		t.Errorf("window options should be resizable by default")
+	}
+	opts.SetFixedSize()
+	if !bitflag.Has(opts.Flags, int(oswin.FixedSize)) {
+		t.Errorf("SetFixedSize should set the fixed-size window flag")
+	}
+	wb := &oswin.WindowBase{Flag: opts.Flags}
+	if !wb.IsFixedSize() {
+		t.Errorf("window fixed-size hint should match the flag")
+	}
+}
+
+func TestDialogCanReopen(t *testing.T) {
+	dlg := &Dialog{}
+	dlg.InitName(dlg, "reusable")
+	dlg.State = DialogAccepted // previously shown and closed
+	if !dlg.CanReopen() {
+		t.Errorf("reusable closed dialog should be reopenable")
+	}
+	dlg.State = DialogOpenModal
+	if dlg.CanReopen() {
+		t.Errorf("currently-open dialog should not be reopenable")
+	}
+	dlg.State = DialogAccepted
+	dlg.SetFlag(int(VpFlagPopupDestroyAll)) // std disposable dialog
+	if dlg.CanReopen() {
+		t.Errorf("destroy-all dialog should not be reopenable")
+	}
+	if dlg.Reopen(0, 0, nil) {
+		t.Errorf("Reopen should reject destroy-all dialog")
+	}
+}
+
+func TestDialogBuild(t *testing.T) {
+	dlg := NewStdDialog(DlgOpts{Title: "Build Test", Prompt: "headless"}, true, true)
+	dlg.UpdateEndNoSig(true)
+	dlg.Build(nil) // no window / event loop
+	frame := dlg.Frame()
+	if prompt, _ := dlg.PromptWidget(frame); prompt == nil || prompt.Text != "headless" {
+		t.Errorf("built dialog should have styled prompt: %v", prompt)
+	}
+	bb, _ := dlg.ButtonBox(frame)
+	if bb == nil {
+		t.Fatalf("built dialog should have button box")
+	}
+	okb := bb.KnownChildByName("ok", 0).Embed(KiT_Button).(*Button)
+	if okb.Text != "Ok" {
+		t.Errorf("ok button should be configured: %v", okb.Text)
+	}
+	// accept / cancel work directly on the built dialog
+	dlg.Accept()
+	if dlg.State != DialogAccepted {
+		t.Errorf("Accept on built dialog: state = %v", dlg.State)
+	}
+}
+
+func TestYesNoCancelDialog(t *testing.T) {
+	clickButton := func(dlg *Dialog, name string) {
+		bb, _ := dlg.ButtonBox(dlg.Frame())
+		b := bb.KnownChildByName(name, 0).Embed(KiT_Button).(*Button)
+		b.ButtonSig.Emit(b.This(), int64(ButtonClicked), nil)
+	}
+	for i, nm := range []string{"yes", "no", "cancel"} {
+		choice := -1
+		dlg := YesNoCancelDialog(nil, DlgOpts{Title: "Save?"}, func(ch int) {
+			choice = ch
+		})
+		clickButton(dlg, nm)
+		if choice != i {
+			t.Errorf("clicking %v should report choice %v, got %v", nm, i, choice)
+		}
+	}
+	// escape / abort maps to Cancel (2)
+	choice := -1
+	dlg := YesNoCancelDialog(nil, DlgOpts{Title: "Save?"}, func(ch int) {
+		choice = ch
+	})
+	dlg.Cancel() // as invoked by the Escape key handler
+	if choice != 2 {
+		t.Errorf("escape should report choice 2, got %v", choice)
+	}
+}
+
+func TestDialogPreferredSize(t *testing.T) {
+	dlg := NewStdDialog(DlgOpts{Title: "Size Test",
+		Prompt: "some prompt text to size"}, true, true)
+	dlg.UpdateEndNoSig(true)
+	sz := dlg.PreferredSize(nil)
+	if sz.X <= 0 || sz.Y <= 0 {
+		t.Fatalf("preferred size should be positive, got %v", sz)
+	}
+	// matches what Open computes for a separate-window dialog: the frame's
+	// preferred size with the dialog's min width / height floors applied
+	frame := dlg.Frame()
+	exp := frame.LayData.Size.Pref.ToPoint()
+	if stw := int(dlg.Sty.Layout.MinWidth.Dots); exp.X < stw {
+		exp.X = stw
+	}
+	if sth := int(dlg.Sty.Layout.MinHeight.Dots); exp.Y < sth {
+		exp.Y = sth
+	}
+	if sz != exp {
+		t.Errorf("preferred size should match Open's computation: %v != %v", sz, exp)
+	}
+	// stable across repeated calls, for caching in DefSize
+	if sz2 := dlg.PreferredSize(nil); sz2 != sz {
+		t.Errorf("preferred size should be stable: %v != %v", sz2, sz)
+	}
+}
+
+func TestDialogSingleFire(t *testing.T) {
+	dlg := NewStdDialog(DlgOpts{Title: "Once", Prompt: "fire once"}, true, true)
+	dlg.UpdateEndNoSig(true)
+	nsig := 0
+	dlg.DialogSig.Connect(dlg.This(), func(recv, send ki.Ki, sig int64, data interface{}) {
+		nsig++
+	})
+	dlg.Accept()
+	dlg.Accept() // double-click on Ok
+	if nsig != 1 {
+		t.Errorf("DialogSig should fire once for double Accept, got %v", nsig)
+	}
+	if dlg.State != DialogAccepted {
+		t.Errorf("state should remain accepted: %v", dlg.State)
+	}
+	// cancel after accept is also a no-op
+	dlg.Cancel()
+	if nsig != 1 || dlg.State != DialogAccepted {
+		t.Errorf("cancel after accept should not fire or change state: %v, %v", nsig, dlg.State)
+	}
+
+	dlg = NewStdDialog(DlgOpts{Title: "Once", Prompt: "fire once"}, true, true)
+	dlg.UpdateEndNoSig(true)
+	nsig = 0
+	dlg.DialogSig.Connect(dlg.This(), func(recv, send ki.Ki, sig int64, data interface{}) {
+		nsig++
+	})
+	dlg.Cancel()
+	dlg.Cancel()
+	if nsig != 1 || dlg.State != DialogCanceled {
+		t.Errorf("DialogSig should fire once for double Cancel, got %v", nsig)
+	}
+}
+
+func TestChoiceDialogCB(t *testing.T) {
+	clickButton := func(dlg *Dialog, name string) {
+		bb, _ := dlg.ButtonBox(dlg.Frame())
+		b := bb.KnownChildByName(name, 0).Embed(KiT_Button).(*Button)
+		b.ButtonSig.Emit(b.This(), int64(ButtonClicked), nil)
+	}
+	choices := []string{"Overwrite", "Rename", "Keep Both", "Cancel"}
+
+	got := -2
+	dlg := ChoiceDialogCB(nil, DlgOpts{Title: "Conflict"}, choices, func(idx int) {
+		got = idx
+	})
+	clickButton(dlg, "keep-both")
+	if got != 2 {
+		t.Errorf("choosing index 2 should report 2, got %v", got)
+	}
+
+	// cancel button reports -1, not its index
+	got = -2
+	dlg = ChoiceDialogCB(nil, DlgOpts{Title: "Conflict"}, choices, func(idx int) {
+		got = idx
+	})
+	clickButton(dlg, "cancel")
+	if got != -1 {
+		t.Errorf("cancel should report -1, got %v", got)
+	}
+
+	// escape / abort also reports -1
+	got = -2
+	dlg = ChoiceDialogCB(nil, DlgOpts{Title: "Conflict"}, choices, func(idx int) {
+		got = idx
+	})
+	dlg.Cancel()
+	if got != -1 {
+		t.Errorf("escape should report -1, got %v", got)
+	}
+}
+
+func TestDialogDefaultButtonName(t *testing.T) {
+	choices := []string{"Save", "Discard", "Cancel"}
+	got := -2
+	dlg := ChoiceDialogCB(nil, DlgOpts{Title: "Quit?"}, choices, func(idx int) {
+		got = idx
+	})
+	// a non-Ok choice as the default action
+	def := dlg.SetDefaultButtonName("discard")
+	if def == nil || def.Nm != "discard" || !def.IsSelected() {
+		t.Fatalf("discard should be designated and shown selected: %v", def)
+	}
+	// Enter anywhere in the dialog activates the designated button
+	if !dlg.ActivateDefaultButton() {
+		t.Fatalf("default button should be activated")
+	}
+	if got != 1 {
+		t.Errorf("default activation should report choice 1 (discard), got %v", got)
+	}
+	if dlg.State != DialogAccepted {
+		t.Errorf("dialog should be accepted: %v", dlg.State)
+	}
+
+	// no designation: falls back on plain Accept
+	dlg = NewStdDialog(DlgOpts{Title: "Plain"}, true, true)
+	dlg.UpdateEndNoSig(true)
+	if dlg.ActivateDefaultButton() {
+		t.Errorf("no default designated: should report fallback")
+	}
+	if dlg.State != DialogAccepted {
+		t.Errorf("fallback should accept the dialog: %v", dlg.State)
+	}
+}
+
+func TestDialogDismissOnClickOutside(t *testing.T) {
+	dlg := &Dialog{}
+	dlg.InitName(dlg, "dismiss-test")
+	dlg.DismissOnClickOutside = true
+	dlg.Geom.Pos = image.Point{100, 100}
+	dlg.Geom.Size = image.Point{200, 100}
+
+	inside := image.Point{150, 150}
+	outside := image.Point{50, 50}
+
+	if dlg.ShouldDismissOnClick(inside) {
+		t.Errorf("click inside dialog bounds should not dismiss")
+	}
+	if !dlg.ShouldDismissOnClick(outside) {
+		t.Errorf("outside click on modeless dialog should dismiss")
+	}
+
+	dlg.Modal = true
+	if dlg.ShouldDismissOnClick(outside) {
+		t.Errorf("modal dialogs should ignore DismissOnClickOutside")
+	}
+
+	// confirm the dismissal path actually cancels the dialog
+	dlg.Modal = false
+	if dlg.ShouldDismissOnClick(outside) {
+		dlg.Cancel()
+	}
+	if dlg.State != DialogCanceled {
+		t.Errorf("outside click should cancel: state = %v", dlg.State)
+	}
+}
+
+func TestDialogQueue(t *testing.T) {
+	svQueue := DialogsQueueModal
+	svStack := ModalDialogStack
+	DialogsQueueModal = true
+	ModalDialogStack = nil
+	defer func() {
+		DialogsQueueModal = svQueue
+		ModalDialogStack = svStack
+		DialogQueue = nil
+	}()
+
+	// an open modal dialog, as Open would record it
+	dlg := NewStdDialog(DlgOpts{Title: "Busy", Prompt: "already open"}, true, true)
+	dlg.UpdateEndNoSig(true)
+	dlg.Modal = true
+	dlg.State = DialogOpenModal
+	ModalDialogPush(dlg)
+
+	// three rapid-fire error dialogs: queued instead of stacking
+	names := []string{"Error One", "Error Two", "Error Three"}
+	for _, nm := range names {
+		ed := NewStdDialog(DlgOpts{Title: nm, Prompt: nm}, true, false)
+		ed.UpdateEndNoSig(true)
+		ed.Modal = true
+		if !ed.Open(0, 0, nil, nil) {
+			t.Fatalf("queued modal Open should report success: %v", nm)
+		}
+	}
+	if PendingDialogCount() != 3 {
+		t.Fatalf("expected 3 pending dialogs, got %v", PendingDialogCount())
+	}
+	if DialogQueue[0].Dlg.Title != "Error One" || DialogQueue[2].Dlg.Title != "Error Three" {
+		t.Errorf("queue should preserve open order: %v, %v", DialogQueue[0].Dlg.Title, DialogQueue[2].Dlg.Title)
+	}
+
+	// closing the open modal shows the first queued dialog
+	ModalDialogPop(dlg)
+	if PendingDialogCount() != 2 {
+		t.Fatalf("pop should dequeue one dialog, %v left", PendingDialogCount())
+	}
+	if DialogQueue[0].Dlg.Title != "Error Two" {
+		t.Errorf("dialogs should show in order: next is %v", DialogQueue[0].Dlg.Title)
+	}
+	// and each subsequent close shows the next, until the queue is empty
+	if !ShowNextQueuedDialog() || PendingDialogCount() != 1 {
+		t.Errorf("second dequeue failed, %v left", PendingDialogCount())
+	}
+	if !ShowNextQueuedDialog() || PendingDialogCount() != 0 {
+		t.Errorf("third dequeue failed, %v left", PendingDialogCount())
+	}
+	if ShowNextQueuedDialog() {
+		t.Errorf("empty queue should report nothing to show")
+	}
+}
+
+func TestDialogUniqueNames(t *testing.T) {
+	// simulate an already-open window for a same-titled dialog
+	win := &Window{}
+	win.InitName(win, "save-file")
+	AllWindows.Add(win)
+	defer AllWindows.Delete(win)
+
+	dlg := NewStdDialog(DlgOpts{Title: "Save File"}, true, true)
+	dlg.UpdateEndNoSig(true)
+	if dlg.Nm == "save-file" {
+		t.Errorf("dialog name should be uniqued against open windows: %v", dlg.Nm)
+	}
+	if dlg.Nm != "save-file-2" {
+		t.Errorf("expected incrementing suffix, got %v", dlg.Nm)
+	}
+
+	// explicit name overrides the title-derived name
+	dlg = NewStdDialog(DlgOpts{Title: "Save File", Name: "my-save-dialog"}, true, true)
+	dlg.UpdateEndNoSig(true)
+	if dlg.Nm != "my-save-dialog" {
+		t.Errorf("explicit Name should override title-derived name: %v", dlg.Nm)
+	}
+
+	// no collision: plain kebab-case name as before
+	dlg = NewStdDialog(DlgOpts{Title: "Open File"}, true, true)
+	dlg.UpdateEndNoSig(true)
+	if dlg.Nm != "open-file" {
+		t.Errorf("uncontested title should keep plain kebab name: %v", dlg.Nm)
+	}
+}
+
+// clampTestApp provides just the screen-geometry portion of the oswin.App
+// interface for testing screen clamping headlessly
+type clampTestApp struct {
+	oswin.App
+	scrn *oswin.Screen
+}
+
+func (ca *clampTestApp) NScreens() int              { return 1 }
+func (ca *clampTestApp) Screen(n int) *oswin.Screen { return ca.scrn }
+
+func TestDialogClampSizeToScreen(t *testing.T) {
+	huge := image.Point{5000, 4000}
+	// no screen info available: unchanged
+	if sz := ClampSizeToScreen(huge, nil); sz != huge {
+		t.Errorf("no screen info should leave size unchanged: %v", sz)
+	}
+
+	svApp := oswin.TheApp
+	oswin.TheApp = &clampTestApp{scrn: &oswin.Screen{Geometry: image.Rect(0, 0, 1920, 1080)}}
+	defer func() { oswin.TheApp = svApp }()
+
+	if sz := ClampSizeToScreen(huge, nil); sz != (image.Point{1920, 1080}) {
+		t.Errorf("huge size should clamp to screen bounds: %v", sz)
+	}
+	small := image.Point{400, 300}
+	if sz := ClampSizeToScreen(small, nil); sz != small {
+		t.Errorf("size within screen should be unchanged: %v", sz)
+	}
+}
+
+func TestDialogResult(t *testing.T) {
+	dlg := StringPromptDialog(nil, "hello", "", DlgOpts{Title: "Test String"}, nil, nil)
+	if res, ok := dlg.Result().(string); !ok || res != "hello" {
+		t.Errorf("string result: got %v, %v", res, ok)
+	}
+	frame := dlg.Frame()
+	tf := frame.KnownChildByName("str-field", 0).(*TextField)
+	tf.SetText("world")
+	if res := dlg.Result().(string); res != "world" {
+		t.Errorf("string result after edit: got %v", res)
+	}
+
+	// number dialog: same accessor pattern, with a SpinBox-backed result as in
+	// NewKiDialog (which cannot be constructed headless due to icon configs)
+	ndlg := NewStdDialog(DlgOpts{Title: "Test Number"}, true, true)
+	nframe := ndlg.Frame()
+	nsb := nframe.AddNewChild(KiT_SpinBox, "n-field").(*SpinBox)
+	nsb.Defaults()
+	nsb.Value = 1
+	ndlg.ResultFunc = func() interface{} {
+		return int(nsb.Value)
+	}
+	ndlg.UpdateEndNoSig(true)
+	if n := ndlg.Result().(int); n != 1 {
+		t.Errorf("number result: got %v", n)
+	}
+	nsb.Value = 3
+	if n := ndlg.Result().(int); n != 3 {
+		t.Errorf("number result after edit: got %v", n)
+	}
+	plain := NewStdDialog(DlgOpts{Title: "Plain"}, false, false)
+	if plain.Result() != nil {
+		t.Errorf("dialog without ResultFunc should return nil result")
+	}
+}
+
+func TestDialogFocusTrap(t *testing.T) {
+	ModalDialogStack = nil
+	defer func() { ModalDialogStack = nil }()
+
+	win := &Window{}
+	win.InitName(win, "focus-trap-win")
+	vp := &Viewport2D{}
+	vp.InitName(vp, "win-vp")
+	vp.Win = win
+	win.Viewport = vp
+	bg := vp.AddNewChild(KiT_TextField, "bg-field").(*TextField)
+	bg.SetFlag(int(CanFocus))
+
+	sep := false
+	dlg := NewStdDialog(DlgOpts{Title: "Trap Test"}, false, false)
+	dlg.Modal = true
+	dlg.SepWindow = &sep
+	dlg.Win = win
+	frame := dlg.Frame()
+	f1 := frame.AddNewChild(KiT_TextField, "f1").(*TextField)
+	f1.SetFlag(int(CanFocus))
+	f2 := frame.AddNewChild(KiT_TextField, "f2").(*TextField)
+	f2.SetFlag(int(CanFocus))
+	dlg.UpdateEndNoSig(true)
+	ModalDialogPush(dlg)
+
+	if win.FocusRoot() != dlg.This() {
+		t.Fatalf("focus root should be the open modal dialog")
+	}
+	win.FocusNext(nil)
+	if win.CurFocus() != f1.This() {
+		t.Fatalf("first focus should be first dialog field: %v", win.CurFocus())
+	}
+	win.FocusNext(win.CurFocus())
+	if win.CurFocus() != f2.This() {
+		t.Fatalf("second focus should be second dialog field: %v", win.CurFocus())
+	}
+	// tabbing past the last focusable widget wraps to the first, not escaping
+	// to the field behind the dialog
+	win.FocusNext(win.CurFocus())
+	if win.CurFocus() != f1.This() {
+		t.Errorf("focus should wrap to first dialog field: %v", win.CurFocus())
+	}
+	// and backwards wraps to the last
+	win.FocusPrev(win.CurFocus())
+	if win.CurFocus() != f2.This() {
+		t.Errorf("focus should wrap back to last dialog field: %v", win.CurFocus())
+	}
+
+	ModalDialogPop(dlg)
+	if win.FocusRoot() != vp.This() {
+		t.Errorf("focus root should revert to viewport after dialog closes")
+	}
+}
+
+// openerTestApp provides just enough of the oswin.App interface for
+// Dialog.Open to run headless (image allocation for the viewport resize)
+type openerTestApp struct {
+	oswin.App
+}
+
+func (ap *openerTestApp) NewImage(sz image.Point) (oswin.Image, error) {
+	return &openerTestImage{rgba: image.NewRGBA(image.Rectangle{Max: sz})}, nil
+}
+
+func (ap *openerTestApp) Platform() oswin.Platforms { return oswin.LinuxX11 }
+func (ap *openerTestApp) NScreens() int             { return 0 }
+
+type openerTestImage struct {
+	oswin.Image
+	rgba *image.RGBA
+}
+
+func (im *openerTestImage) RGBA() *image.RGBA { return im.rgba }
+func (im *openerTestImage) Release()          {}
+
+func TestDialogOpenerWin(t *testing.T) {
+	ModalDialogStack = nil
+	svApp := oswin.TheApp
+	defer func() {
+		ModalDialogStack = nil
+		oswin.TheApp = svApp
+	}()
+	oswin.TheApp = &openerTestApp{}
+
+	win := &Window{}
+	win.InitName(win, "opener-win")
+	vp := &Viewport2D{}
+	vp.InitName(vp, "opener-vp")
+	vp.Win = win
+	win.Viewport = vp
+
+	dlg := NewStdDialog(DlgOpts{Title: "Opened"}, true, true)
+	dlg.SetSepWindow(false)
+	dlg.UpdateEndNoSig(true)
+	if !dlg.Open(0, 0, vp, nil) {
+		t.Fatalf("Open failed")
+	}
+	if dlg.OpenerWin != win {
+		t.Errorf("Open should record the opener window: got %v", dlg.OpenerWin)
+	}
+}
+
+// dpiTestOSWin provides just the LogicalDPI part of the oswin.Window
+// interface for testing DPI-dependent sizing headless
+type dpiTestOSWin struct {
+	oswin.Window
+	dpi float32
+}
+
+func (w *dpiTestOSWin) LogicalDPI() float32 { return w.dpi }
+
+func TestDialogWinSizeHint(t *testing.T) {
+	// no window info: standard-DPI default
+	if sz := DialogWinSizeHint(nil); sz != (image.Point{100, 100}) {
+		t.Errorf("nil window should give standard 100x100: %v", sz)
+	}
+	win := &Window{}
+	win.InitName(win, "dpi-win")
+	if sz := DialogWinSizeHint(win); sz != (image.Point{100, 100}) {
+		t.Errorf("window without OSWin should give standard 100x100: %v", sz)
+	}
+	// the initial size scales with logical DPI
+	win.OSWin = &dpiTestOSWin{dpi: 192}
+	if sz := DialogWinSizeHint(win); sz != (image.Point{200, 200}) {
+		t.Errorf("2x DPI should give 200x200: %v", sz)
+	}
+	win.OSWin = &dpiTestOSWin{dpi: 144}
+	if sz := DialogWinSizeHint(win); sz != (image.Point{150, 150}) {
+		t.Errorf("1.5x DPI should give 150x150: %v", sz)
+	}
+}
+
+func TestDialogButtonText(t *testing.T) {
+	dlg := NewStdDialog(DlgOpts{Title: "Delete Item", Prompt: "Really delete?",
+		OkText: "Delete", CancelText: "Keep", Destructive: true}, true, true)
+	dlg.UpdateEndNoSig(true)
+	okb := dlg.ButtonByName("ok")
+	if okb == nil || okb.Text != "Delete" {
+		t.Fatalf("OkText should relabel the ok button: %v", okb)
+	}
+	canb := dlg.ButtonByName("cancel")
+	if canb == nil || canb.Text != "Keep" {
+		t.Fatalf("CancelText should relabel the cancel button: %v", canb)
+	}
+	if _, has := okb.Prop("background-color"); !has {
+		t.Errorf("Destructive should style the ok button background")
+	}
+	if _, has := canb.Prop("background-color"); has {
+		t.Errorf("cancel button should keep its default styling")
+	}
+	// defaults stay generic
+	dlg2 := NewStdDialog(DlgOpts{Title: "Plain"}, true, true)
+	dlg2.UpdateEndNoSig(true)
+	if b := dlg2.ButtonByName("ok"); b == nil || b.Text != "Ok" {
+		t.Errorf("without OkText, ok button should stay generic: %v", b)
+	}
+}
+
+func TestDialogSizeConstraints(t *testing.T) {
+	ModalDialogStack = nil
+	svApp := oswin.TheApp
+	defer func() {
+		ModalDialogStack = nil
+		oswin.TheApp = svApp
+	}()
+	oswin.TheApp = &openerTestApp{}
+
+	win := &Window{}
+	win.InitName(win, "size-win")
+	vp := &Viewport2D{}
+	vp.InitName(vp, "size-vp")
+	vp.Win = win
+	win.Viewport = vp
+
+	// tiny content, explicit min: opens at the min
+	dlg := NewStdDialog(DlgOpts{Title: "Tiny"}, true, true)
+	dlg.SetSepWindow(false)
+	dlg.SetMinSize(Vec2D{400, 300})
+	dlg.UpdateEndNoSig(true)
+	if !dlg.Open(0, 0, vp, nil) {
+		t.Fatalf("Open failed")
+	}
+	if dlg.Geom.Size != (image.Point{400, 300}) {
+		t.Errorf("tiny content with min size should open at the min: %v", dlg.Geom.Size)
+	}
+
+	// huge content (via DefSize), explicit max: capped, with scrolling
+	dlg2 := NewStdDialog(DlgOpts{Title: "Huge"}, true, true)
+	dlg2.SetSepWindow(false)
+	dlg2.DefSize = image.Point{800, 600}
+	dlg2.SetMaxSize(Vec2D{300, 200})
+	dlg2.UpdateEndNoSig(true)
+	if !dlg2.Open(0, 0, vp, nil) {
+		t.Fatalf("Open failed")
+	}
+	if dlg2.Geom.Size != (image.Point{300, 200}) {
+		t.Errorf("huge content with max size should be capped: %v", dlg2.Geom.Size)
+	}
+	if _, has := dlg2.Frame().Prop("overflow"); !has {
+		t.Errorf("capped dialog frame should scroll overflowing content")
+	}
+}
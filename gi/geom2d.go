@@ -5,6 +5,7 @@
 package gi
 
 import (
+	"encoding/json"
 	"fmt"
 	"image"
 	"log"
@@ -14,6 +15,7 @@ import (
 	"unicode"
 
 	"github.com/chewxy/math32"
+	"github.com/goki/gi/units"
 	"github.com/goki/ki"
 	"github.com/goki/ki/kit"
 
@@ -184,6 +186,15 @@ func (ev *Dims2D) UnmarshalJSON(b []byte) error { return kit.EnumUnmarshalJSON(e
 
 //go:generate stringer -type=Dims2D
 
+// Dims2DSet is a bitmask of Dims2D dimensions, e.g., for reporting which
+// components of a per-component comparison are true
+type Dims2DSet int32
+
+// Has returns whether given dimension is in the set
+func (ds Dims2DSet) Has(d Dims2D) bool {
+	return ds&(1<<uint(d)) != 0
+}
+
 // 2D vector -- a point or size in 2D
 type Vec2D struct {
 	X, Y float32
@@ -201,12 +212,40 @@ func NewVec2DFmPoint(pt image.Point) Vec2D {
 	return v
 }
 
+// Vec2DFromPointDelta returns the delta from one image.Point to another as a
+// Vec2D -- for converting mouse / drag deltas to float coordinates
+func Vec2DFromPointDelta(from, to image.Point) Vec2D {
+	return Vec2D{float32(to.X - from.X), float32(to.Y - from.Y)}
+}
+
 func NewVec2DFmFixed(pt fixed.Point26_6) Vec2D {
 	v := Vec2D{}
 	v.SetFixed(pt)
 	return v
 }
 
+// Vec2DFromArray returns a Vec2D from a [2]float32 array (e.g., f32.Vec2)
+func Vec2DFromArray(ar [2]float32) Vec2D {
+	return Vec2D{ar[0], ar[1]}
+}
+
+// Vec2DFromArray64 returns a Vec2D from a [2]float64 array (e.g., f64.Vec2)
+func Vec2DFromArray64(ar [2]float64) Vec2D {
+	return Vec2D{float32(ar[0]), float32(ar[1])}
+}
+
+// Array returns the vector as a [2]float32 array, for interop with other
+// math libraries (e.g., f32.Vec2)
+func (a Vec2D) Array() [2]float32 {
+	return [2]float32{a.X, a.Y}
+}
+
+// Array64 returns the vector as a [2]float64 array, for interop with other
+// math libraries (e.g., f64.Vec2)
+func (a Vec2D) Array64() [2]float64 {
+	return [2]float64{float64(a.X), float64(a.Y)}
+}
+
 // return value along given dimension
 func (a Vec2D) Dim(d Dims2D) float32 {
 	switch d {
@@ -217,6 +256,32 @@ func (a Vec2D) Dim(d Dims2D) float32 {
 	}
 }
 
+// MaxDim returns the dimension (axis) with the larger value -- X wins ties
+func (a Vec2D) MaxDim() Dims2D {
+	if a.Y > a.X {
+		return Y
+	}
+	return X
+}
+
+// MinDim returns the dimension (axis) with the smaller value -- Y wins ties
+func (a Vec2D) MinDim() Dims2D {
+	if a.Y > a.X {
+		return X
+	}
+	return Y
+}
+
+// MaxVal returns the larger of the two component values
+func (a Vec2D) MaxVal() float32 {
+	return math32.Max(a.X, a.Y)
+}
+
+// MinVal returns the smaller of the two component values
+func (a Vec2D) MinVal() float32 {
+	return math32.Min(a.X, a.Y)
+}
+
 // get the other dimension
 func OtherDim(d Dims2D) Dims2D {
 	switch d {
@@ -249,10 +314,20 @@ func (a *Vec2D) SetVal(val float32) {
 	a.Y = val
 }
 
+// set both dims to zero
+func (a *Vec2D) SetZero() {
+	a.SetVal(0)
+}
+
 func (a Vec2D) IsZero() bool {
 	return a.X == 0.0 && a.Y == 0.0
 }
 
+// IsEqualVal returns true if both X and Y are equal to given value
+func (a Vec2D) IsEqualVal(val float32) bool {
+	return a.X == val && a.Y == val
+}
+
 func (a Vec2D) Fixed() fixed.Point26_6 {
 	return Float32ToFixedPoint(a.X, a.Y)
 }
@@ -365,6 +440,28 @@ func (a Vec2D) DivVal(val float32) Vec2D {
 	return Vec2D{a.X / val, a.Y / val}
 }
 
+// DivSafe returns the element-wise quotient a / b, with 0 instead of Inf /
+// NaN for any axis where b is 0 -- for ratios of possibly-empty sizes
+func (a Vec2D) DivSafe(b Vec2D) Vec2D {
+	r := Vec2DZero
+	if b.X != 0 {
+		r.X = a.X / b.X
+	}
+	if b.Y != 0 {
+		r.Y = a.Y / b.Y
+	}
+	return r
+}
+
+// DivValSafe returns the vector divided by a scalar, returning Vec2DZero if
+// the divisor is 0 -- see DivSafe
+func (a Vec2D) DivValSafe(val float32) Vec2D {
+	if val == 0 {
+		return Vec2DZero
+	}
+	return Vec2D{a.X / val, a.Y / val}
+}
+
 func (a Vec2D) Max(b Vec2D) Vec2D {
 	return Vec2D{Max32(a.X, b.X), Max32(a.Y, b.Y)}
 }
@@ -444,6 +541,28 @@ func (a *Vec2D) SetMinPosDim(d Dims2D, val float32) {
 	}
 }
 
+// Negate returns the vector with both components negated
+func (a Vec2D) Negate() Vec2D {
+	return Vec2D{-a.X, -a.Y}
+}
+
+// SetNegate negates both components in place
+func (a *Vec2D) SetNegate() {
+	a.X = -a.X
+	a.Y = -a.Y
+}
+
+// Swap returns the vector with the X and Y components exchanged -- for
+// transposing coordinates, e.g., when flipping a layout orientation
+func (a Vec2D) Swap() Vec2D {
+	return Vec2D{a.Y, a.X}
+}
+
+// SetSwap exchanges the X and Y components in place
+func (a *Vec2D) SetSwap() {
+	a.X, a.Y = a.Y, a.X
+}
+
 func (a Vec2D) Abs() Vec2D {
 	b := a
 	if b.X < 0 {
@@ -481,6 +600,19 @@ func (a Vec2D) ToPointRound() image.Point {
 	return image.Point{int(math.Round(float64(a.X))), int(math.Round(float64(a.Y)))}
 }
 
+// ToGeomSize returns the vector as an integer size for viewport allocation,
+// using Ceil so that the allocated size is never smaller than the float size
+func (a Vec2D) ToGeomSize() image.Point {
+	return a.ToPointCeil()
+}
+
+// ToGeomPos returns the vector as an integer position for viewport
+// allocation, using Floor so that the position never overshoots the float
+// position
+func (a Vec2D) ToGeomPos() image.Point {
+	return a.ToPointFloor()
+}
+
 // RectFromPosSizeMax returns an image.Rectangle from max dims of pos, size
 // (floor on pos, ceil on size)
 func RectFromPosSizeMax(pos, sz Vec2D) image.Rectangle {
@@ -497,20 +629,445 @@ func RectFromPosSizeMin(pos, sz Vec2D) image.Rectangle {
 	return image.Rect(tp.X, tp.Y, tp.X+ts.X, tp.Y+ts.Y)
 }
 
+// RoundToMultiple returns the vector with each component rounded to the
+// nearest multiple of the corresponding component of mult -- a zero mult
+// component leaves that axis unchanged
+func (a Vec2D) RoundToMultiple(mult Vec2D) Vec2D {
+	r := a
+	if mult.X != 0 {
+		r.X = FloatMod32(a.X, mult.X)
+	}
+	if mult.Y != 0 {
+		r.Y = FloatMod32(a.Y, mult.Y)
+	}
+	return r
+}
+
+// Quantize returns the vector snapped to the nearest multiple of grid on
+// both axes -- because float equality is exact-bits, nearly-coincident points
+// make distinct map keys, so callers doing spatial deduplication (e.g.,
+// vertex welding) should use the quantized value as the map key, not the raw
+// one.  A zero or negative grid returns the vector unchanged.
+func (a Vec2D) Quantize(grid float32) Vec2D {
+	if grid <= 0 {
+		return a
+	}
+	return Vec2D{FloatMod32(a.X, grid), FloatMod32(a.Y, grid)}
+}
+
+// DivMod returns the integer tile cell that the point falls in, and the
+// fractional remainder within the cell, for given tile size -- uses floor
+// semantics so cells tile correctly across the origin (negative coordinates
+// get negative cells with positive remainders).  A zero or negative tile
+// axis returns a zero cell and the point itself as frac.
+func (a Vec2D) DivMod(tile Vec2D) (cell image.Point, frac Vec2D) {
+	if tile.X <= 0 || tile.Y <= 0 {
+		return image.ZP, a
+	}
+	cx := math32.Floor(a.X / tile.X)
+	cy := math32.Floor(a.Y / tile.Y)
+	cell = image.Point{int(cx), int(cy)}
+	frac = Vec2D{a.X - cx*tile.X, a.Y - cy*tile.Y}
+	return
+}
+
+// Vec2DFromUnits returns a Vec2D in dots from the Dots fields of a pair of
+// units.Value -- the values must already have been converted via ToDots
+func Vec2DFromUnits(x, y units.Value) Vec2D {
+	return Vec2D{x.Dots, y.Dots}
+}
+
+// ToUnitsPx returns the vector as a pair of Px units.Value -- Dots remains 0
+// until set from a units.Context via ToDots
+func (a Vec2D) ToUnitsPx() (x, y units.Value) {
+	x = units.NewValue(a.X, units.Px)
+	y = units.NewValue(a.Y, units.Px)
+	return
+}
+
+// AspectRatio returns the X / Y aspect ratio, and 0 if Y is 0
+func (a Vec2D) AspectRatio() float32 {
+	if a.Y == 0 {
+		return 0
+	}
+	return a.X / a.Y
+}
+
+// FitInto returns the largest size with a's aspect ratio that fits within
+// given bounds (the "meet" scaling rule)
+func (a Vec2D) FitInto(bounds Vec2D) Vec2D {
+	if a.X == 0 || a.Y == 0 {
+		return Vec2DZero
+	}
+	return FitSize(a, bounds, true)
+}
+
+// FitSize returns the scaled content size that preserves its aspect ratio
+// while either fitting entirely inside the container (meet = true) or
+// covering it (meet = false, the "slice" rule) -- the scalar core of
+// ViewBox / image scaling logic
+func FitSize(content, container Vec2D, meet bool) Vec2D {
+	if content.X == 0 || content.Y == 0 {
+		return Vec2DZero
+	}
+	sx := container.X / content.X
+	sy := container.Y / content.Y
+	var sc float32
+	if meet {
+		sc = Min32(sx, sy)
+	} else {
+		sc = Max32(sx, sy)
+	}
+	return content.MulVal(sc)
+}
+
+// Truncate returns the vector with each component truncated to given level
+// of precision -- reduces coordinate precision for smaller serialized output
+// (e.g., exporting simplified SVG paths)
+func (a Vec2D) Truncate(prec int) Vec2D {
+	return Vec2D{Truncate32(a.X, prec), Truncate32(a.Y, prec)}
+}
+
+// ScaleFrom returns the point scaled toward / away from given pivot point by
+// given factor: pivot + (a - pivot) * factor -- e.g., for zooming points
+// around a gesture center without building a matrix
+func (a Vec2D) ScaleFrom(pivot Vec2D, factor float32) Vec2D {
+	return pivot.Add(a.Sub(pivot).MulVal(factor))
+}
+
 func (a Vec2D) Distance(b Vec2D) float32 {
 	return math32.Hypot(a.X-b.X, a.Y-b.Y)
 }
 
+// Hypot returns the length (magnitude) of the vector -- Hypot(X, Y)
+func (a Vec2D) Hypot() float32 {
+	return math32.Hypot(a.X, a.Y)
+}
+
+// NormalizeOr returns the unit-length version of the vector, or the given
+// default when the vector is zero-length and has no direction -- lets callers
+// supply a sensible fallback direction
+func (a Vec2D) NormalizeOr(def Vec2D) Vec2D {
+	ln := a.Hypot()
+	if ln == 0 {
+		return def
+	}
+	return a.DivVal(ln)
+}
+
+// SnapToNearest returns the nearest of the given candidate points within
+// maxDist of this point, and whether one was found -- for snapping a dragged
+// point to alignment guides -- ties go to the earlier candidate
+func (a Vec2D) SnapToNearest(candidates []Vec2D, maxDist float32) (Vec2D, bool) {
+	best := Vec2DZero
+	bestd := maxDist
+	got := false
+	for _, c := range candidates {
+		d := a.Distance(c)
+		if d < bestd || (!got && d == bestd) {
+			best = c
+			bestd = d
+			got = true
+		}
+	}
+	return best, got
+}
+
+// SnapPolar returns the point snapped in polar coordinates relative to
+// center: the angle is rounded to the nearest multiple of angleStep (in
+// radians) and the radius to the nearest multiple of radiusStep,
+// independently -- a zero (or negative) step leaves that component as-is.
+// For rotation / radius drag handles that snap to increments (e.g., 15
+// degree angle steps).
+func (a Vec2D) SnapPolar(center Vec2D, angleStep, radiusStep float32) Vec2D {
+	d := a.Sub(center)
+	ang := math32.Atan2(d.Y, d.X)
+	rad := d.Hypot()
+	if angleStep > 0 {
+		ang = math32.Floor(ang/angleStep+0.5) * angleStep
+	}
+	if radiusStep > 0 {
+		rad = math32.Floor(rad/radiusStep+0.5) * radiusStep
+	}
+	return Vec2D{center.X + rad*math32.Cos(ang), center.Y + rad*math32.Sin(ang)}
+}
+
+// Dot returns the dot product of the two vectors
+func (a Vec2D) Dot(b Vec2D) float32 {
+	return a.X*b.X + a.Y*b.Y
+}
+
+// Cross returns the 2D cross product (z component of the 3D cross product)
+// of the two vectors: a.X*b.Y - a.Y*b.X
+func (a Vec2D) Cross(b Vec2D) float32 {
+	return a.X*b.Y - a.Y*b.X
+}
+
+// GreaterDims returns the set of dimensions where the component of a is
+// greater than that of b -- expresses layout branching such as "which
+// dimensions exceed the limit" compactly
+func (a Vec2D) GreaterDims(b Vec2D) Dims2DSet {
+	var ds Dims2DSet
+	if a.X > b.X {
+		ds |= 1 << uint(X)
+	}
+	if a.Y > b.Y {
+		ds |= 1 << uint(Y)
+	}
+	return ds
+}
+
+// LessDims returns the set of dimensions where the component of a is less
+// than that of b
+func (a Vec2D) LessDims(b Vec2D) Dims2DSet {
+	var ds Dims2DSet
+	if a.X < b.X {
+		ds |= 1 << uint(X)
+	}
+	if a.Y < b.Y {
+		ds |= 1 << uint(Y)
+	}
+	return ds
+}
+
+// Apply returns the vector with given function applied to both components --
+// for custom per-component transforms such as log scaling an axis
+func (a Vec2D) Apply(f func(float32) float32) Vec2D {
+	return Vec2D{f(a.X), f(a.Y)}
+}
+
+// Rotate returns the vector rotated counter-clockwise by given angle in
+// radians -- for rotating many points by the same angle, compute the sin /
+// cos pair once and use RotateSinCos
+func (a Vec2D) Rotate(angle float32) Vec2D {
+	return a.RotateSinCos(math32.Sin(angle), math32.Cos(angle))
+}
+
+// RotateSinCos returns the vector rotated by the angle whose sin and cos are
+// given -- avoids recomputing them on every call when rotating a slice of
+// points by the same angle
+func (a Vec2D) RotateSinCos(sin, cos float32) Vec2D {
+	return Vec2D{a.X*cos - a.Y*sin, a.X*sin + a.Y*cos}
+}
+
+// SegmentIntersect returns the intersection point of segments a1-a2 and
+// b1-b2, with ok = false for parallel segments or segments that do not
+// overlap within their extents
+func SegmentIntersect(a1, a2, b1, b2 Vec2D) (pt Vec2D, ok bool) {
+	da := a2.Sub(a1)
+	db := b2.Sub(b1)
+	den := da.Cross(db)
+	if den == 0 {
+		return Vec2DZero, false
+	}
+	d := b1.Sub(a1)
+	t := d.Cross(db) / den
+	u := d.Cross(da) / den
+	if t < 0 || t > 1 || u < 0 || u > 1 {
+		return Vec2DZero, false
+	}
+	return a1.Add(da.MulVal(t)), true
+}
+
+// SegmentParam returns the parameter t in [0,1] of the point on segment ab
+// closest to p: the projection of p onto the segment, clamped to the
+// endpoints -- 0 for a degenerate (zero-length) segment
+func SegmentParam(p, a, b Vec2D) float32 {
+	ab := b.Sub(a)
+	d2 := ab.Dot(ab)
+	if d2 == 0 {
+		return 0
+	}
+	t := p.Sub(a).Dot(ab) / d2
+	return Min32(Max32(t, 0), 1)
+}
+
+// ClosestPointOnSegment returns the point on segment ab closest to p, for
+// snapping to path edges -- the projection of p onto the segment, clamped to
+// the endpoints (see SegmentParam for the parameter)
+func ClosestPointOnSegment(p, a, b Vec2D) Vec2D {
+	return a.Add(b.Sub(a).MulVal(SegmentParam(p, a, b)))
+}
+
+// PointOnSegment returns whether pt lies exactly on the line segment from a
+// to b (inclusive of the endpoints)
+func PointOnSegment(pt, a, b Vec2D) bool {
+	ab := b.Sub(a)
+	ap := pt.Sub(a)
+	if ab.Cross(ap) != 0 {
+		return false
+	}
+	d := ab.Dot(ap)
+	return d >= 0 && d <= ab.Dot(ab)
+}
+
+// PointInPolygon returns whether pt is inside the given polygon, using the
+// even-odd ray casting rule, consistent with the SVG fill-rule="evenodd" --
+// points exactly on an edge count as inside
+func PointInPolygon(pt Vec2D, poly []Vec2D) bool {
+	n := len(poly)
+	if n < 3 {
+		return false
+	}
+	in := false
+	j := n - 1
+	for i := 0; i < n; i++ {
+		pi := poly[i]
+		pj := poly[j]
+		if PointOnSegment(pt, pj, pi) {
+			return true
+		}
+		if (pi.Y > pt.Y) != (pj.Y > pt.Y) {
+			xint := pi.X + (pt.Y-pi.Y)*(pj.X-pi.X)/(pj.Y-pi.Y)
+			if pt.X < xint {
+				in = !in
+			}
+		}
+		j = i
+	}
+	return in
+}
+
+// BoundingCircle returns a circle enclosing all of the given points: the
+// centroid of the points and the max distance from it -- a simple, not
+// minimal, enclosing circle, sufficient for focus-ring / selection-halo
+// rendering.  Empty input returns Vec2DZero, 0.
+func BoundingCircle(pts []Vec2D) (center Vec2D, radius float32) {
+	n := len(pts)
+	if n == 0 {
+		return Vec2DZero, 0
+	}
+	for _, p := range pts {
+		center.SetAdd(p)
+	}
+	center = center.DivVal(float32(n))
+	for _, p := range pts {
+		radius = Max32(radius, center.Distance(p))
+	}
+	return center, radius
+}
+
+// PointInPolygonNonZero returns whether pt is inside the given polygon,
+// using the nonzero winding number rule, consistent with the SVG default
+// fill-rule="nonzero" -- points exactly on an edge count as inside
+func PointInPolygonNonZero(pt Vec2D, poly []Vec2D) bool {
+	n := len(poly)
+	if n < 3 {
+		return false
+	}
+	wn := 0
+	j := n - 1
+	for i := 0; i < n; i++ {
+		pi := poly[i]
+		pj := poly[j]
+		if PointOnSegment(pt, pj, pi) {
+			return true
+		}
+		if pj.Y <= pt.Y {
+			if pi.Y > pt.Y && pi.Sub(pj).Cross(pt.Sub(pj)) > 0 {
+				wn++
+			}
+		} else {
+			if pi.Y <= pt.Y && pi.Sub(pj).Cross(pt.Sub(pj)) < 0 {
+				wn--
+			}
+		}
+		j = i
+	}
+	return wn != 0
+}
+
 func (a Vec2D) Interpolate(b Vec2D, t float32) Vec2D {
 	x := a.X + (b.X-a.X)*t
 	y := a.Y + (b.Y-a.Y)*t
 	return Vec2D{x, y}
 }
 
+// EaseInQuad is the quadratic ease-in function: slow start, accelerating --
+// t is the animation progress in [0, 1]
+func EaseInQuad(t float32) float32 {
+	return t * t
+}
+
+// EaseOutQuad is the quadratic ease-out function: fast start, decelerating
+func EaseOutQuad(t float32) float32 {
+	return t * (2 - t)
+}
+
+// EaseInOutCubic is the cubic ease-in-out function: slow at both ends
+func EaseInOutCubic(t float32) float32 {
+	if t < 0.5 {
+		return 4 * t * t * t
+	}
+	u := 2*t - 2
+	return 1 + u*u*u/2
+}
+
+// Ease interpolates between this vector and b at progress t in [0, 1], with
+// the given easing function shaping the progress (e.g., EaseInOutCubic) --
+// for smooth widget / dialog animations
+func (a Vec2D) Ease(b Vec2D, t float32, ease func(float32) float32) Vec2D {
+	if ease != nil {
+		t = ease(t)
+	}
+	return a.Interpolate(b, t)
+}
+
 func (a Vec2D) String() string {
 	return fmt.Sprintf("(%v, %v)", a.X, a.Y)
 }
 
+////////////////////////////////////////////////////////////////////////////////////////
+//  Bézier curves
+
+// QuadBezier returns the point at parameter t (in 0..1) along the quadratic
+// Bézier curve defined by start point p0, control point p1, and end point p2
+func QuadBezier(p0, p1, p2 Vec2D, t float32) Vec2D {
+	u := 1 - t
+	x := u*u*p0.X + 2*u*t*p1.X + t*t*p2.X
+	y := u*u*p0.Y + 2*u*t*p1.Y + t*t*p2.Y
+	return Vec2D{x, y}
+}
+
+// CubicBezier returns the point at parameter t (in 0..1) along the cubic
+// Bézier curve defined by start point p0, control points p1, p2, and end
+// point p3
+func CubicBezier(p0, p1, p2, p3 Vec2D, t float32) Vec2D {
+	u := 1 - t
+	x := u*u*u*p0.X + 3*u*u*t*p1.X + 3*u*t*t*p2.X + t*t*t*p3.X
+	y := u*u*u*p0.Y + 3*u*u*t*p1.Y + 3*u*t*t*p2.Y + t*t*t*p3.Y
+	return Vec2D{x, y}
+}
+
+// FlattenCubic flattens the cubic Bézier curve defined by p0..p3 into a
+// polyline, using adaptive subdivision (De Casteljau) so that the midpoint of
+// each segment is within tol of the curve -- returns the points including
+// both endpoints
+func FlattenCubic(p0, p1, p2, p3 Vec2D, tol float32) []Vec2D {
+	pts := []Vec2D{p0}
+	flattenCubicSeg(p0, p1, p2, p3, tol, 0, &pts)
+	return append(pts, p3)
+}
+
+// flattenCubicSeg recursively subdivides the curve, appending interior points
+// to pts -- depth guards against runaway recursion for degenerate inputs
+func flattenCubicSeg(p0, p1, p2, p3 Vec2D, tol float32, depth int, pts *[]Vec2D) {
+	mid := CubicBezier(p0, p1, p2, p3, .5)
+	lmid := p0.Interpolate(p3, .5)
+	if depth >= 16 || mid.Distance(lmid) <= tol {
+		return
+	}
+	// split at t = .5 via De Casteljau
+	p01 := p0.Interpolate(p1, .5)
+	p12 := p1.Interpolate(p2, .5)
+	p23 := p2.Interpolate(p3, .5)
+	p012 := p01.Interpolate(p12, .5)
+	p123 := p12.Interpolate(p23, .5)
+	flattenCubicSeg(p0, p01, p012, mid, tol, depth+1, pts)
+	*pts = append(*pts, mid)
+	flattenCubicSeg(mid, p123, p23, p3, tol, depth+1, pts)
+}
+
 ////////////////////////////////////////////////////////////////////////////////////////
 // Matrix2D
 
@@ -578,6 +1135,58 @@ func Skew2D(x, y float32) Matrix2D {
 	}
 }
 
+// RectToRectMatrix returns the transform mapping the src rectangle (position
+// and size) onto the dst rectangle, either stretching each axis
+// independently (uniform false) or preserving aspect ratio and centering
+// within dst (uniform true, like viewBox Meet) -- for "fit this drawing into
+// this frame" operations.  A zero-size src axis is mapped with scale 1 (pure
+// translation on that axis).
+func RectToRectMatrix(srcPos, srcSz, dstPos, dstSz Vec2D, uniform bool) Matrix2D {
+	sc := dstSz.DivSafe(srcSz)
+	if srcSz.X == 0 {
+		sc.X = 1
+	}
+	if srcSz.Y == 0 {
+		sc.Y = 1
+	}
+	if uniform {
+		u := Min32(sc.X, sc.Y)
+		sc = Vec2D{u, u}
+	}
+	off := dstPos.Sub(srcPos.Mul(sc))
+	if uniform {
+		off.SetAdd(dstSz.Sub(srcSz.Mul(sc)).MulVal(0.5))
+	}
+	return Matrix2D{sc.X, 0, 0, sc.Y, off.X, off.Y}
+}
+
+// Matrix2DMapRect returns the translate + scale transform mapping the src
+// rectangle exactly onto dst, stretching each axis independently -- an
+// image.Rectangle convenience wrapper around RectToRectMatrix (non-uniform)
+// for image placement and the non-uniform viewBox case.
+func Matrix2DMapRect(src, dst image.Rectangle) Matrix2D {
+	return RectToRectMatrix(NewVec2DFmPoint(src.Min), NewVec2DFmPoint(src.Size()),
+		NewVec2DFmPoint(dst.Min), NewVec2DFmPoint(dst.Size()), false)
+}
+
+// SetTranslate sets the matrix to a pure translation by x, y in place --
+// in-place version of Translate2D for imperative transform update loops
+func (a *Matrix2D) SetTranslate(x, y float32) {
+	*a = Translate2D(x, y)
+}
+
+// SetScale sets the matrix to a pure scale by x, y in place -- in-place
+// version of Scale2D
+func (a *Matrix2D) SetScale(x, y float32) {
+	*a = Scale2D(x, y)
+}
+
+// SetRotate sets the matrix to a pure rotation by given angle (in radians)
+// in place -- in-place version of Rotate2D
+func (a *Matrix2D) SetRotate(angle float32) {
+	*a = Rotate2D(angle)
+}
+
 func (a Matrix2D) Multiply(b Matrix2D) Matrix2D {
 	return Matrix2D{
 		a.XX*b.XX + a.YX*b.XY,
@@ -613,12 +1222,56 @@ func (a Matrix2D) TransformPointVec2D(v Vec2D) Vec2D {
 	return Vec2D{tx, ty}
 }
 
+// TransformFixed transforms a fixed.Point26_6 through the matrix, converting
+// to float32, transforming, and converting back with rounding, to preserve
+// sub-pixel precision in text / glyph placement
+func (a Matrix2D) TransformFixed(p fixed.Point26_6) fixed.Point26_6 {
+	tx, ty := a.TransformPoint(FixedToFloat32(p.X), FixedToFloat32(p.Y))
+	return fixed.Point26_6{X: fixed.Int26_6(math.Round(float64(tx * 64))), Y: fixed.Int26_6(math.Round(float64(ty * 64)))}
+}
+
+// TransformRect transforms the given rectangle through the matrix, returning
+// the axis-aligned bounding box of the transformed corners (floor on min,
+// ceil on max so the result always covers the transformed region)
+func (a Matrix2D) TransformRect(r image.Rectangle) image.Rectangle {
+	min := NewVec2DFmPoint(r.Min)
+	max := NewVec2DFmPoint(r.Max)
+	crs := [4]Vec2D{a.TransformPointVec2D(min),
+		a.TransformPointVec2D(Vec2D{max.X, min.Y}),
+		a.TransformPointVec2D(Vec2D{min.X, max.Y}),
+		a.TransformPointVec2D(max)}
+	tmin := crs[0]
+	tmax := crs[0]
+	for _, c := range crs[1:] {
+		tmin.SetMin(c)
+		tmax.SetMax(c)
+	}
+	return image.Rectangle{Min: tmin.ToPointFloor(), Max: tmax.ToPointCeil()}
+}
+
+// TransformGeom2DInt transforms the given integer geom's bounding box through
+// the matrix, returning the resulting axis-aligned integer geom
+func (a Matrix2D) TransformGeom2DInt(gm Geom2DInt) Geom2DInt {
+	tgm := Geom2DInt{}
+	tgm.SetRect(a.TransformRect(gm.Bounds()))
+	return tgm
+}
+
 func (a Matrix2D) TransformPointToInt(x, y float32) (tx, ty int) {
 	tx = int(a.XX*x + a.XY*y + a.X0)
 	ty = int(a.YX*x + a.YY*y + a.Y0)
 	return
 }
 
+// TransformVectorToInt transforms given vector through the linear (non
+// translation) part of the matrix only, rounding to nearest int -- for
+// direction / delta quantities such as scroll deltas
+func (a Matrix2D) TransformVectorToInt(x, y float32) (tx, ty int) {
+	tx = int(math.Round(float64(a.XX*x + a.XY*y)))
+	ty = int(math.Round(float64(a.YX*x + a.YY*y)))
+	return
+}
+
 func (a Matrix2D) Translate(x, y float32) Matrix2D {
 	return Translate2D(x, y).Multiply(a)
 }
@@ -635,6 +1288,18 @@ func (a Matrix2D) Shear(x, y float32) Matrix2D {
 	return Shear2D(x, y).Multiply(a)
 }
 
+// FlipX reflects across the vertical line at the given x coordinate --
+// composes translate, scale(-1,1), translate -- for mirroring shapes
+func (a Matrix2D) FlipX(aboutX float32) Matrix2D {
+	return a.Translate(aboutX, 0).Scale(-1, 1).Translate(-aboutX, 0)
+}
+
+// FlipY reflects across the horizontal line at the given y coordinate --
+// composes translate, scale(1,-1), translate -- for mirroring shapes
+func (a Matrix2D) FlipY(aboutY float32) Matrix2D {
+	return a.Translate(0, aboutY).Scale(1, -1).Translate(0, -aboutY)
+}
+
 func (a Matrix2D) Skew(x, y float32) Matrix2D {
 	return Skew2D(x, y).Multiply(a)
 }
@@ -658,6 +1323,34 @@ func (a Matrix2D) ExtractScale() (scx, scy float32) {
 	return
 }
 
+// ExtractScaleSigned extracts the X and Y scale factors as in ExtractScale,
+// but preserves the sign of a negative (reflection) scale, which
+// ExtractScale folds into a positive scale plus extra rotation -- a negative
+// determinant means one axis is flipped, attributed to the axis whose
+// diagonal element is negative, so flipped elements round-trip in editors
+func (a Matrix2D) ExtractScaleSigned() (scx, scy float32) {
+	scx = math32.Hypot(a.XX, a.YX)
+	scy = math32.Hypot(a.XY, a.YY)
+	if a.XX*a.YY-a.YX*a.XY < 0 { // reflection
+		if a.XX < 0 {
+			scx = -scx
+		} else {
+			scy = -scy
+		}
+	}
+	return
+}
+
+// ExtractSkew extracts the skew (x-axis shear) angle in radians remaining
+// after removing the rotation and scale components, rounding out the
+// decomposition accessors along with ExtractRot and ExtractScale -- assumes
+// the matrix was composed as rotate * skew * scale
+func (a Matrix2D) ExtractSkew() float32 {
+	dot := a.XX*a.XY + a.YX*a.YY
+	crs := a.XX*a.YY - a.YX*a.XY
+	return math32.Atan2(dot, crs)
+}
+
 // ParseFloat32 logs any strconv.ParseFloat errors
 func ParseFloat32(pstr string) (float32, error) {
 	r, err := strconv.ParseFloat(pstr, 32)
@@ -702,6 +1395,18 @@ func ParseAngle32(pstr string) (float32, error) {
 // ReadPoints reads a set of floating point values from a SVG format number
 // string -- returns a slice or nil if there was an error
 func ReadPoints(pstr string) []float32 {
+	pts, _, err := ReadPointsErr(pstr)
+	if err != nil {
+		return nil
+	}
+	return pts
+}
+
+// ReadPointsErr reads a set of floating point values from a SVG format
+// number string, returning the points parsed before any failure, the byte
+// offset at which parsing failed, and an error naming the bad substring --
+// on success the offset is len(pstr) and the error is nil
+func ReadPointsErr(pstr string) ([]float32, int, error) {
 	lastIdx := -1
 	var pts []float32
 	lr := ' '
@@ -711,14 +1416,22 @@ func ReadPoints(pstr string) []float32 {
 				s := pstr[lastIdx:i]
 				p, err := ParseFloat32(s)
 				if err != nil {
-					return nil
+					return pts, lastIdx, fmt.Errorf("gi.ReadPoints: bad number %q at offset %v", s, lastIdx)
 				}
 				pts = append(pts, p)
 			}
 			if r == '-' {
 				lastIdx = i
-			} else {
+			} else if unicode.IsSpace(r) || r == ',' {
 				lastIdx = -1
+			} else {
+				end := strings.IndexFunc(pstr[i:], func(sr rune) bool {
+					return unicode.IsSpace(sr) || sr == ','
+				})
+				if end < 0 {
+					end = len(pstr) - i
+				}
+				return pts, i, fmt.Errorf("gi.ReadPoints: bad number %q at offset %v", pstr[i:i+end], i)
 			}
 		} else if lastIdx == -1 {
 			lastIdx = i
@@ -729,11 +1442,11 @@ func ReadPoints(pstr string) []float32 {
 		s := pstr[lastIdx:len(pstr)]
 		p, err := ParseFloat32(s)
 		if err != nil {
-			return nil
+			return pts, lastIdx, fmt.Errorf("gi.ReadPoints: bad number %q at offset %v", s, lastIdx)
 		}
 		pts = append(pts, p)
 	}
-	return pts
+	return pts, len(pstr), nil
 }
 
 // PointsCheckN checks the number of points read and emits an error if not equal to n
@@ -749,7 +1462,7 @@ func (a *Matrix2D) SetString(str string) error {
 	errmsg := "gi.Matrix2D SetString"
 	str = strings.ToLower(strings.TrimSpace(str))
 	*a = Identity2D()
-	if str == "none" {
+	if str == "none" || str == "" { // empty = no transform, same as none
 		return nil
 	}
 	// could have multiple transforms
@@ -766,9 +1479,9 @@ func (a *Matrix2D) SetString(str string) error {
 		eidx := strings.IndexByte(vals, ')')
 		if eidx > 0 {
 			nxt = strings.TrimSpace(vals[eidx+1:])
-			if strings.HasPrefix(nxt, ";") {
-				nxt = strings.TrimSpace(strings.TrimPrefix(nxt, ";"))
-			}
+			// skip any separators to the next command -- transforms can be
+			// directly adjacent or separated by spaces, commas, or semicolons
+			nxt = strings.TrimSpace(strings.TrimLeft(nxt, ";,"))
 			vals = vals[:eidx]
 		}
 		pts := ReadPoints(vals)
@@ -802,6 +1515,10 @@ func (a *Matrix2D) SetString(str string) error {
 				*a = a.Scale(pts[0], pts[0])
 			} else if len(pts) == 2 {
 				*a = a.Scale(pts[0], pts[1])
+			} else if len(pts) == 4 {
+				// scale about a center point -- nonstandard extension
+				// emitted by some tools: scale(sx, sy, cx, cy)
+				*a = a.Translate(pts[2], pts[3]).Scale(pts[0], pts[1]).Translate(-pts[2], -pts[3])
 			} else {
 				err := fmt.Errorf("%v incorrect number of points: 2 != %v\n", errmsg, len(pts))
 				log.Println(err)
@@ -857,6 +1574,142 @@ func (a *Matrix2D) SetString(str string) error {
 	return nil
 }
 
+// SetStringOrigin processes the standard SVG-style transform strings as in
+// SetString, but applies the resulting transform about the given origin point
+// instead of about zero (translate to origin, apply, translate back) -- which
+// is what transform-origin / transform-box: fill-box rendering needs
+func (a *Matrix2D) SetStringOrigin(str string, origin Vec2D) error {
+	err := a.SetString(str)
+	if err != nil {
+		return err
+	}
+	*a = a.Multiply(Translate2D(origin.X, origin.Y)).Translate(-origin.X, -origin.Y)
+	return nil
+}
+
+// String returns the standard SVG matrix(..) string form of the transform,
+// which SetString parses back -- the inverse operation
+func (a Matrix2D) String() string {
+	return fmt.Sprintf("matrix(%v,%v,%v,%v,%v,%v)", a.XX, a.YX, a.XY, a.YY, a.X0, a.Y0)
+}
+
+// MarshalJSON saves the transform in its compact SVG matrix(..) string form
+// (see String) instead of the default six-field object
+func (a Matrix2D) MarshalJSON() ([]byte, error) {
+	return json.Marshal(a.String())
+}
+
+// UnmarshalJSON restores the transform from the SVG string form saved by
+// MarshalJSON -- for backward compatibility it also accepts the old
+// six-field object form
+func (a *Matrix2D) UnmarshalJSON(b []byte) error {
+	if len(b) > 0 && b[0] == '"' {
+		var str string
+		if err := json.Unmarshal(b, &str); err != nil {
+			return err
+		}
+		return a.SetString(str)
+	}
+	type matFields Matrix2D // local alias type avoids recursing back here
+	return json.Unmarshal(b, (*matFields)(a))
+}
+
+// KappaBezier is the factor for approximating a quarter circle with a cubic
+// bezier: control points at radius * KappaBezier from the endpoints
+const KappaBezier = float32(0.5522848)
+
+// RoundedRectPoints returns the outline of a rounded rectangle with given
+// corner radii as a point sequence, in clockwise order starting after the
+// top-left corner.  rx and ry are clamped to half the width / height.  For
+// zero radii just the four corners are returned.  Otherwise each corner
+// contributes four points after the start point: the straight-edge endpoint,
+// two cubic bezier control points, and the corner endpoint -- the final
+// point closes the outline, equal to the start point.
+func RoundedRectPoints(r image.Rectangle, rx, ry float32) []Vec2D {
+	x0 := float32(r.Min.X)
+	y0 := float32(r.Min.Y)
+	x1 := float32(r.Max.X)
+	y1 := float32(r.Max.Y)
+	if rx <= 0 || ry <= 0 {
+		return []Vec2D{{x0, y0}, {x1, y0}, {x1, y1}, {x0, y1}}
+	}
+	rx = Min32(rx, (x1-x0)/2)
+	ry = Min32(ry, (y1-y0)/2)
+	kx := rx * KappaBezier
+	ky := ry * KappaBezier
+	return []Vec2D{
+		{x0 + rx, y0},
+		{x1 - rx, y0}, {x1 - rx + kx, y0}, {x1, y0 + ry - ky}, {x1, y0 + ry}, // top-right
+		{x1, y1 - ry}, {x1, y1 - ry + ky}, {x1 - rx + kx, y1}, {x1 - rx, y1}, // bottom-right
+		{x0 + rx, y1}, {x0 + rx - kx, y1}, {x0, y1 - ry + ky}, {x0, y1 - ry}, // bottom-left
+		{x0, y0 + ry}, {x0, y0 + ry - ky}, {x0 + rx - kx, y0}, {x0 + rx, y0}, // top-left
+	}
+}
+
+////////////////////////////////////////////////////////////////////////////////////////
+// Anchor
+
+// Anchor specifies a side or corner of a target rectangle, for placing a
+// popup or tooltip adjacent to it (compass directions, plus Center for
+// directly on top of the target)
+type Anchor int32
+
+const (
+	AnchorNorth Anchor = iota
+	AnchorNorthEast
+	AnchorEast
+	AnchorSouthEast
+	AnchorSouth
+	AnchorSouthWest
+	AnchorWest
+	AnchorNorthWest
+	AnchorCenter
+	AnchorsN
+)
+
+var KiT_Anchor = kit.Enums.AddEnumAltLower(AnchorsN, false, nil, "Anchor")
+
+func (ev Anchor) MarshalJSON() ([]byte, error)  { return kit.EnumMarshalJSON(ev) }
+func (ev *Anchor) UnmarshalJSON(b []byte) error { return kit.EnumUnmarshalJSON(ev, b) }
+
+//go:generate stringer -type=Anchor
+
+// PlaceRect computes the position for a rect of given size placed adjacent
+// to (or, for Center, on top of) the target rect per the given anchor, and
+// clamps the result so the rect remains within screen -- use for positioning
+// popups / tooltips relative to a widget
+func PlaceRect(size Vec2D, target image.Rectangle, anchor Anchor, screen image.Rectangle) Vec2D {
+	tmin := NewVec2DFmPoint(target.Min)
+	tmax := NewVec2DFmPoint(target.Max)
+	ctr := tmin.Add(tmax).MulVal(.5)
+	var pos Vec2D
+	switch anchor {
+	case AnchorNorth:
+		pos = Vec2D{ctr.X - size.X/2, tmin.Y - size.Y}
+	case AnchorNorthEast:
+		pos = Vec2D{tmax.X, tmin.Y - size.Y}
+	case AnchorEast:
+		pos = Vec2D{tmax.X, ctr.Y - size.Y/2}
+	case AnchorSouthEast:
+		pos = Vec2D{tmax.X, tmax.Y}
+	case AnchorSouth:
+		pos = Vec2D{ctr.X - size.X/2, tmax.Y}
+	case AnchorSouthWest:
+		pos = Vec2D{tmin.X - size.X, tmax.Y}
+	case AnchorWest:
+		pos = Vec2D{tmin.X - size.X, ctr.Y - size.Y/2}
+	case AnchorNorthWest:
+		pos = Vec2D{tmin.X - size.X, tmin.Y - size.Y}
+	case AnchorCenter:
+		pos = ctr.Sub(size.MulVal(.5))
+	}
+	smin := NewVec2DFmPoint(screen.Min)
+	smax := NewVec2DFmPoint(screen.Max).Sub(size)
+	pos.SetMin(smax)
+	pos.SetMax(smin)
+	return pos
+}
+
 ////////////////////////////////////////////////////////////////////////////////////////
 // Geom2DInt
 
@@ -868,6 +1721,12 @@ type Geom2DInt struct {
 	Size image.Point
 }
 
+// String returns a compact representation for debugging layout issues,
+// matching the Vec2D.String style
+func (gm Geom2DInt) String() string {
+	return fmt.Sprintf("{Pos:(%v,%v) Size:(%v,%v)}", gm.Pos.X, gm.Pos.Y, gm.Size.X, gm.Size.Y)
+}
+
 // Bounds converts geom to equivalent image.Rectangle
 func (gm *Geom2DInt) Bounds() image.Rectangle {
 	return image.Rect(gm.Pos.X, gm.Pos.Y, gm.Pos.X+gm.Size.X, gm.Pos.Y+gm.Size.Y)
@@ -884,6 +1743,26 @@ func (gm *Geom2DInt) SetRect(r image.Rectangle) {
 	gm.Size = r.Size()
 }
 
+// PosVec returns the position as a Vec2D
+func (gm *Geom2DInt) PosVec() Vec2D {
+	return NewVec2DFmPoint(gm.Pos)
+}
+
+// SizeVec returns the size as a Vec2D
+func (gm *Geom2DInt) SizeVec() Vec2D {
+	return NewVec2DFmPoint(gm.Size)
+}
+
+// SetPosVec sets the position from a Vec2D, rounding to nearest int
+func (gm *Geom2DInt) SetPosVec(pos Vec2D) {
+	gm.Pos = pos.ToPointRound()
+}
+
+// SetSizeVec sets the size from a Vec2D, rounding to nearest int
+func (gm *Geom2DInt) SetSizeVec(sz Vec2D) {
+	gm.Size = sz.ToPointRound()
+}
+
 ///////////////////////////////////////////////////////////
 // utilities
 
@@ -895,6 +1774,25 @@ func Degrees(radians float32) float32 {
 	return radians * 180 / math32.Pi
 }
 
+// NormalizeAngle wraps given angle in radians into the range [0, 2*Pi) --
+// for comparing or displaying accumulated rotations (e.g., from ExtractRot)
+func NormalizeAngle(rad float32) float32 {
+	rad = math32.Mod(rad, 2*math32.Pi)
+	if rad < 0 {
+		rad += 2 * math32.Pi
+	}
+	return rad
+}
+
+// NormalizeAngleSigned wraps given angle in radians into the range (-Pi, Pi]
+func NormalizeAngleSigned(rad float32) float32 {
+	rad = NormalizeAngle(rad)
+	if rad > math32.Pi {
+		rad -= 2 * math32.Pi
+	}
+	return rad
+}
+
 func Float32ToFixedPoint(x, y float32) fixed.Point26_6 {
 	return fixed.Point26_6{X: Float32ToFixed(x), Y: Float32ToFixed(y)}
 }
@@ -903,6 +1801,21 @@ func Float32ToFixed(x float32) fixed.Int26_6 {
 	return fixed.Int26_6(x * 64)
 }
 
+// Float32ToFixedRect converts a rectangle given by pos and size Vec2Ds to a
+// fixed.Rectangle26_6, with Min = pos and Max = pos + size -- for carrying
+// glyph / path bounding boxes without losing sub-pixel precision
+func Float32ToFixedRect(pos, sz Vec2D) fixed.Rectangle26_6 {
+	return fixed.Rectangle26_6{Min: pos.Fixed(), Max: pos.Add(sz).Fixed()}
+}
+
+// FixedRectToFloat32 converts a fixed.Rectangle26_6 back to pos and size
+// Vec2Ds -- inverse of Float32ToFixedRect
+func FixedRectToFloat32(r fixed.Rectangle26_6) (pos, sz Vec2D) {
+	pos = NewVec2DFmFixed(r.Min)
+	sz = NewVec2DFmFixed(r.Max).Sub(pos)
+	return
+}
+
 func FixedToFloat32(x fixed.Int26_6) float32 {
 	const shift, mask = 6, 1<<6 - 1
 	if x >= 0 {
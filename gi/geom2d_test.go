@@ -0,0 +1,1094 @@
+// Copyright (c) 2019, The GoKi Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package gi
+
+import (
+	"encoding/json"
+	"image"
+	"strings"
+	"testing"
+
+	"github.com/chewxy/math32"
+	"github.com/goki/gi/units"
+)
+
+func TestVec2DNegate(t *testing.T) {
+	v := Vec2D{2, -3}
+	n := v.Negate()
+	if n.X != -2 || n.Y != 3 {
+		t.Errorf("Negate: expected (-2, 3), got %v", n)
+	}
+	if v.X != 2 || v.Y != -3 {
+		t.Errorf("Negate modified receiver: %v", v)
+	}
+	v.SetNegate()
+	if v.X != -2 || v.Y != 3 {
+		t.Errorf("SetNegate: expected (-2, 3), got %v", v)
+	}
+}
+
+func TestBezier(t *testing.T) {
+	p0 := Vec2D{0, 0}
+	p1 := Vec2D{1, 2}
+	p2 := Vec2D{3, 2}
+	p3 := Vec2D{4, 0}
+
+	if qv := QuadBezier(p0, p1, p2, 0); qv != p0 {
+		t.Errorf("QuadBezier t=0: expected %v, got %v", p0, qv)
+	}
+	if qv := QuadBezier(p0, p1, p2, 1); qv != p2 {
+		t.Errorf("QuadBezier t=1: expected %v, got %v", p2, qv)
+	}
+	if qv := QuadBezier(p0, p1, p2, .5); qv != (Vec2D{1.25, 1.5}) {
+		t.Errorf("QuadBezier t=.5: got %v", qv)
+	}
+
+	if cv := CubicBezier(p0, p1, p2, p3, 0); cv != p0 {
+		t.Errorf("CubicBezier t=0: expected %v, got %v", p0, cv)
+	}
+	if cv := CubicBezier(p0, p1, p2, p3, 1); cv != p3 {
+		t.Errorf("CubicBezier t=1: expected %v, got %v", p3, cv)
+	}
+	if cv := CubicBezier(p0, p1, p2, p3, .5); cv != (Vec2D{2, 1.5}) {
+		t.Errorf("CubicBezier t=.5: got %v", cv)
+	}
+}
+
+func TestFlattenCubic(t *testing.T) {
+	p0 := Vec2D{0, 0}
+	p1 := Vec2D{1, 2}
+	p2 := Vec2D{3, 2}
+	p3 := Vec2D{4, 0}
+	coarse := FlattenCubic(p0, p1, p2, p3, .5)
+	fine := FlattenCubic(p0, p1, p2, p3, .01)
+	if len(coarse) < 2 || coarse[0] != p0 || coarse[len(coarse)-1] != p3 {
+		t.Errorf("FlattenCubic endpoints: got %v", coarse)
+	}
+	if len(fine) <= len(coarse) {
+		t.Errorf("FlattenCubic density should scale with tolerance: %v !> %v", len(fine), len(coarse))
+	}
+}
+
+func TestVec2DRoundToMultiple(t *testing.T) {
+	v := Vec2D{23, 7.6}
+	r := v.RoundToMultiple(Vec2D{10, 2.5})
+	if r != (Vec2D{20, 7.5}) {
+		t.Errorf("RoundToMultiple non-uniform: got %v", r)
+	}
+	// zero component leaves that axis unchanged
+	r = v.RoundToMultiple(Vec2D{10, 0})
+	if r != (Vec2D{20, 7.6}) {
+		t.Errorf("RoundToMultiple zero guard: got %v", r)
+	}
+}
+
+func TestVec2DDivMod(t *testing.T) {
+	tile := Vec2D{10, 10}
+	cell, frac := (Vec2D{25, 37}).DivMod(tile)
+	if cell != (image.Point{2, 3}) || frac != (Vec2D{5, 7}) {
+		t.Errorf("DivMod positive: got cell %v, frac %v", cell, frac)
+	}
+	// floor semantics across the origin: -3 is in cell -1 with offset 7
+	cell, frac = (Vec2D{-3, -13}).DivMod(tile)
+	if cell != (image.Point{-1, -2}) || frac != (Vec2D{7, 7}) {
+		t.Errorf("DivMod negative: got cell %v, frac %v", cell, frac)
+	}
+	// non-positive tile: zero cell, point unchanged as frac
+	cell, frac = (Vec2D{25, 37}).DivMod(Vec2D{0, 10})
+	if cell != image.ZP || frac != (Vec2D{25, 37}) {
+		t.Errorf("DivMod zero tile: got cell %v, frac %v", cell, frac)
+	}
+}
+
+func TestVec2DUnits(t *testing.T) {
+	v := Vec2D{3, 4}
+	x, y := v.ToUnitsPx()
+	if x.Un != units.Px || y.Un != units.Px || x.Val != 3 || y.Val != 4 {
+		t.Errorf("ToUnitsPx: got %v, %v", x, y)
+	}
+	var uc units.Context
+	uc.Defaults() // px = dots at standard DPI
+	x.ToDots(&uc)
+	y.ToDots(&uc)
+	rt := Vec2DFromUnits(x, y)
+	if rt != v {
+		t.Errorf("units round-trip: expected %v, got %v", v, rt)
+	}
+}
+
+func TestVec2DTruncate(t *testing.T) {
+	v := Vec2D{1.23456789, -9.87654321}
+	tv := v.Truncate(4)
+	if tv != (Vec2D{1.235, -9.877}) {
+		t.Errorf("Truncate(4): got %v", tv)
+	}
+	if v.Truncate(2) != (Vec2D{1.2, -9.9}) {
+		t.Errorf("Truncate(2): got %v", v.Truncate(2))
+	}
+}
+
+func TestVec2DScaleFrom(t *testing.T) {
+	pivot := Vec2D{10, 10}
+	if sp := pivot.ScaleFrom(pivot, 2); sp != pivot {
+		t.Errorf("pivot should be fixed: got %v", sp)
+	}
+	p := Vec2D{12, 13}
+	sp := p.ScaleFrom(pivot, 2)
+	if sp != (Vec2D{14, 16}) {
+		t.Errorf("factor 2 should double distance from pivot: got %v", sp)
+	}
+	if sp.Distance(pivot) != 2*p.Distance(pivot) {
+		t.Errorf("distance should double: %v vs %v", sp.Distance(pivot), p.Distance(pivot))
+	}
+}
+
+func TestVec2DAspectFit(t *testing.T) {
+	if ar := (Vec2D{3, 3}).AspectRatio(); ar != 1 {
+		t.Errorf("AspectRatio square: expected 1, got %v", ar)
+	}
+	if ar := (Vec2D{4, 2}).AspectRatio(); ar != 2 {
+		t.Errorf("AspectRatio: expected 2, got %v", ar)
+	}
+	if ar := (Vec2D{4, 0}).AspectRatio(); ar != 0 {
+		t.Errorf("AspectRatio with zero Y: expected 0, got %v", ar)
+	}
+	// portrait into landscape: height-limited
+	fit := (Vec2D{100, 200}).FitInto(Vec2D{400, 300})
+	if fit != (Vec2D{150, 300}) {
+		t.Errorf("FitInto portrait-into-landscape: got %v", fit)
+	}
+	// square into square: fills it
+	fit = (Vec2D{50, 50}).FitInto(Vec2D{200, 200})
+	if fit != (Vec2D{200, 200}) {
+		t.Errorf("FitInto square: got %v", fit)
+	}
+}
+
+func TestFitSize(t *testing.T) {
+	// wide content into tall container
+	wide := Vec2D{200, 100}
+	tall := Vec2D{100, 400}
+	if fit := FitSize(wide, tall, true); fit != (Vec2D{100, 50}) {
+		t.Errorf("FitSize meet wide-into-tall: got %v", fit)
+	}
+	if fit := FitSize(wide, tall, false); fit != (Vec2D{800, 400}) {
+		t.Errorf("FitSize slice wide-into-tall: got %v", fit)
+	}
+	// tall content into wide container
+	tallc := Vec2D{100, 200}
+	widec := Vec2D{400, 100}
+	if fit := FitSize(tallc, widec, true); fit != (Vec2D{50, 100}) {
+		t.Errorf("FitSize meet tall-into-wide: got %v", fit)
+	}
+	if fit := FitSize(tallc, widec, false); fit != (Vec2D{400, 800}) {
+		t.Errorf("FitSize slice tall-into-wide: got %v", fit)
+	}
+}
+
+func TestMatrix2DTransformFixed(t *testing.T) {
+	p := Float32ToFixedPoint(1.5, 2.5)
+	tp := Translate2D(2, 3).TransformFixed(p)
+	if tp != Float32ToFixedPoint(3.5, 5.5) {
+		t.Errorf("TransformFixed translate: got %v", tp)
+	}
+	sp := Scale2D(2, 4).TransformFixed(p)
+	if sp != Float32ToFixedPoint(3, 10) {
+		t.Errorf("TransformFixed scale: got %v", sp)
+	}
+}
+
+func TestRoundedRectPoints(t *testing.T) {
+	r := image.Rect(0, 0, 100, 50)
+	// zero radius: plain rect corners
+	pts := RoundedRectPoints(r, 0, 0)
+	if len(pts) != 4 {
+		t.Fatalf("zero radius should give 4 corners, got %v", len(pts))
+	}
+	if pts[0] != (Vec2D{0, 0}) || pts[2] != (Vec2D{100, 50}) {
+		t.Errorf("zero radius corners wrong: %v", pts)
+	}
+	// rounded: closed outline, on-curve points inset by the radii
+	pts = RoundedRectPoints(r, 10, 5)
+	if len(pts) != 17 {
+		t.Fatalf("rounded outline should have 17 points, got %v", len(pts))
+	}
+	if pts[0] != (Vec2D{10, 0}) || pts[0] != pts[16] {
+		t.Errorf("outline should start and close at top-left corner start: %v vs %v", pts[0], pts[16])
+	}
+	if pts[4] != (Vec2D{100, 5}) {
+		t.Errorf("top-right corner end: got %v", pts[4])
+	}
+	// over-large radius clamps to half width / height
+	pts = RoundedRectPoints(r, 500, 500)
+	if pts[0] != (Vec2D{50, 0}) || pts[4] != (Vec2D{100, 25}) {
+		t.Errorf("over-large radius should clamp to half dims: %v, %v", pts[0], pts[4])
+	}
+}
+
+func TestPlaceRect(t *testing.T) {
+	size := Vec2D{20, 10}
+	target := image.Rect(100, 100, 140, 120) // center (120, 110)
+	screen := image.Rect(0, 0, 400, 300)
+	cases := []struct {
+		anchor Anchor
+		want   Vec2D
+	}{
+		{AnchorNorth, Vec2D{110, 90}},
+		{AnchorNorthEast, Vec2D{140, 90}},
+		{AnchorEast, Vec2D{140, 105}},
+		{AnchorSouthEast, Vec2D{140, 120}},
+		{AnchorSouth, Vec2D{110, 120}},
+		{AnchorSouthWest, Vec2D{80, 120}},
+		{AnchorWest, Vec2D{80, 105}},
+		{AnchorNorthWest, Vec2D{80, 90}},
+		{AnchorCenter, Vec2D{110, 105}},
+	}
+	for _, c := range cases {
+		if pos := PlaceRect(size, target, c.anchor, screen); pos != c.want {
+			t.Errorf("PlaceRect %v: expected %v, got %v", c.anchor, c.want, pos)
+		}
+	}
+	// clamping: target at the screen edge pushes placement back on-screen
+	edge := image.Rect(0, 0, 40, 20)
+	if pos := PlaceRect(size, edge, AnchorNorth, screen); pos != (Vec2D{10, 0}) {
+		t.Errorf("PlaceRect clamp north: got %v", pos)
+	}
+	if pos := PlaceRect(size, image.Rect(380, 280, 400, 300), AnchorSouthEast, screen); pos != (Vec2D{380, 290}) {
+		t.Errorf("PlaceRect clamp south-east: got %v", pos)
+	}
+}
+
+func TestMatrix2DTransformGeom2DInt(t *testing.T) {
+	gm := Geom2DInt{Pos: image.Point{10, 20}, Size: image.Point{30, 40}}
+	tgm := Translate2D(5, -5).TransformGeom2DInt(gm)
+	if tgm != (Geom2DInt{Pos: image.Point{15, 15}, Size: image.Point{30, 40}}) {
+		t.Errorf("TransformGeom2DInt translate: got %v", tgm)
+	}
+	// 45 degree rotation of a square: axis-aligned bbox grows to diagonal
+	sq := Geom2DInt{Size: image.Point{100, 100}}
+	rgm := Rotate2D(math32.Pi / 4).TransformGeom2DInt(sq)
+	if rgm.Size.X < 141 || rgm.Size.X > 143 || rgm.Size.Y < 141 || rgm.Size.Y > 143 {
+		t.Errorf("TransformGeom2DInt rotate: bbox should be ~diagonal sized, got %v", rgm)
+	}
+}
+
+func TestBoundingCircle(t *testing.T) {
+	sq := []Vec2D{{0, 0}, {10, 0}, {10, 10}, {0, 10}}
+	ctr, rad := BoundingCircle(sq)
+	if ctr != (Vec2D{5, 5}) {
+		t.Errorf("center should be middle of square, got %v", ctr)
+	}
+	exp := ctr.Distance(Vec2D{0, 0}) // to a corner
+	if rad != exp {
+		t.Errorf("radius should reach corner: expected %v, got %v", exp, rad)
+	}
+	// single point: zero radius at the point
+	ctr, rad = BoundingCircle(sq[:1])
+	if ctr != sq[0] || rad != 0 {
+		t.Errorf("single point: got %v, %v", ctr, rad)
+	}
+	// empty input
+	ctr, rad = BoundingCircle(nil)
+	if ctr != Vec2DZero || rad != 0 {
+		t.Errorf("empty input should return zero values: %v, %v", ctr, rad)
+	}
+}
+
+func TestPointInPolygon(t *testing.T) {
+	quad := []Vec2D{{0, 0}, {10, 0}, {10, 10}, {0, 10}}
+	if !PointInPolygon(Vec2D{5, 5}, quad) {
+		t.Errorf("center of convex quad should be inside")
+	}
+	if PointInPolygon(Vec2D{15, 5}, quad) {
+		t.Errorf("point right of quad should be outside")
+	}
+	if !PointInPolygon(Vec2D{10, 5}, quad) {
+		t.Errorf("point exactly on an edge should count as inside")
+	}
+	if !PointInPolygon(Vec2D{0, 0}, quad) {
+		t.Errorf("vertex should count as inside")
+	}
+
+	// concave "U" shape: the notch between the arms is outside
+	concave := []Vec2D{{0, 0}, {10, 0}, {10, 10}, {7, 10}, {7, 3}, {3, 3}, {3, 10}, {0, 10}}
+	if !PointInPolygon(Vec2D{1, 5}, concave) {
+		t.Errorf("left arm of concave shape should be inside")
+	}
+	if PointInPolygon(Vec2D{5, 8}, concave) {
+		t.Errorf("notch of concave shape should be outside")
+	}
+	if !PointInPolygon(Vec2D{5, 1}, concave) {
+		t.Errorf("base of concave shape should be inside")
+	}
+
+	// nonzero winding agrees on simple (non-self-intersecting) polygons
+	for _, tc := range []struct {
+		pt   Vec2D
+		poly []Vec2D
+		in   bool
+	}{
+		{Vec2D{5, 5}, quad, true},
+		{Vec2D{15, 5}, quad, false},
+		{Vec2D{10, 5}, quad, true},
+		{Vec2D{5, 8}, concave, false},
+		{Vec2D{1, 5}, concave, true},
+	} {
+		if got := PointInPolygonNonZero(tc.pt, tc.poly); got != tc.in {
+			t.Errorf("PointInPolygonNonZero(%v): expected %v, got %v", tc.pt, tc.in, got)
+		}
+	}
+
+	// degenerate polygons are never hit
+	if PointInPolygon(Vec2D{0, 0}, quad[:2]) || PointInPolygonNonZero(Vec2D{0, 0}, quad[:2]) {
+		t.Errorf("degenerate polygon should not contain any point")
+	}
+}
+
+func TestGeom2DIntVecAccessors(t *testing.T) {
+	gm := Geom2DInt{Pos: image.Point{10, 20}, Size: image.Point{30, 40}}
+	if gm.PosVec() != (Vec2D{10, 20}) {
+		t.Errorf("PosVec: got %v", gm.PosVec())
+	}
+	if gm.SizeVec() != (Vec2D{30, 40}) {
+		t.Errorf("SizeVec: got %v", gm.SizeVec())
+	}
+	// setters round to nearest int
+	gm.SetPosVec(Vec2D{1.4, 2.6})
+	if gm.Pos != (image.Point{1, 3}) {
+		t.Errorf("SetPosVec rounding: got %v", gm.Pos)
+	}
+	gm.SetSizeVec(Vec2D{9.5, 10.49})
+	if gm.Size != (image.Point{10, 10}) {
+		t.Errorf("SetSizeVec rounding: got %v", gm.Size)
+	}
+}
+
+func TestVec2DDivSafe(t *testing.T) {
+	a := Vec2D{10, 20}
+	if r := a.DivSafe(Vec2D{2, 4}); r != (Vec2D{5, 5}) {
+		t.Errorf("DivSafe with nonzero divisor: got %v", r)
+	}
+	if r := a.DivSafe(Vec2D{0, 4}); r != (Vec2D{0, 5}) {
+		t.Errorf("DivSafe with zero X divisor: got %v", r)
+	}
+	if r := a.DivSafe(Vec2D{2, 0}); r != (Vec2D{5, 0}) {
+		t.Errorf("DivSafe with zero Y divisor: got %v", r)
+	}
+	if r := a.DivSafe(Vec2DZero); r != Vec2DZero {
+		t.Errorf("DivSafe with zero divisor on both axes: got %v", r)
+	}
+	if r := a.DivValSafe(2); r != (Vec2D{5, 10}) {
+		t.Errorf("DivValSafe with nonzero divisor: got %v", r)
+	}
+	if r := a.DivValSafe(0); r != Vec2DZero {
+		t.Errorf("DivValSafe with zero divisor: got %v", r)
+	}
+}
+
+func TestFixedRectRoundTrip(t *testing.T) {
+	// multiples of 1/64 are exactly representable in 26.6 fixed point
+	pos := Vec2D{1.5, -2.25}
+	sz := Vec2D{10.125, 20.5}
+	fr := Float32ToFixedRect(pos, sz)
+	rpos, rsz := FixedRectToFloat32(fr)
+	if rpos != pos || rsz != sz {
+		t.Errorf("round trip: expected %v %v, got %v %v", pos, sz, rpos, rsz)
+	}
+	// corners map to Min / Max
+	if fr.Min != pos.Fixed() || fr.Max != pos.Add(sz).Fixed() {
+		t.Errorf("fixed rect corners wrong: %v", fr)
+	}
+}
+
+func TestMatrix2DInPlaceSetters(t *testing.T) {
+	var a Matrix2D
+	a.SetTranslate(3, -4)
+	if a != Translate2D(3, -4) {
+		t.Errorf("SetTranslate should match Translate2D: %v", a)
+	}
+	a.SetScale(2, 0.5)
+	if a != Scale2D(2, 0.5) {
+		t.Errorf("SetScale should match Scale2D: %v", a)
+	}
+	a.SetRotate(math32.Pi / 3)
+	if a != Rotate2D(math32.Pi/3) {
+		t.Errorf("SetRotate should match Rotate2D: %v", a)
+	}
+}
+
+func TestReadPointsErr(t *testing.T) {
+	pts, off, err := ReadPointsErr("1 2 x 4")
+	if err == nil {
+		t.Fatalf("malformed string should return an error")
+	}
+	if off != 4 || "1 2 x 4"[off] != 'x' {
+		t.Errorf("offset should point at the bad substring: got %v", off)
+	}
+	if len(pts) != 2 || pts[0] != 1 || pts[1] != 2 {
+		t.Errorf("points before the failure should be returned: %v", pts)
+	}
+	if ReadPoints("1 2 x 4") != nil {
+		t.Errorf("ReadPoints should return nil on error")
+	}
+	// valid input: offset is end of string, no error
+	pts, off, err = ReadPointsErr("1,2 -3.5 4e-2")
+	if err != nil || off != len("1,2 -3.5 4e-2") {
+		t.Fatalf("valid input: err = %v, off = %v", err, off)
+	}
+	if len(pts) != 4 || pts[2] != -3.5 {
+		t.Errorf("valid input should parse all points: %v", pts)
+	}
+}
+
+func TestVec2DQuantize(t *testing.T) {
+	grid := float32(0.001)
+	a := Vec2D{1.0000001, 2.0}
+	b := Vec2D{1.0, 1.9999999}
+	if a == b {
+		t.Fatalf("raw near-points should differ as map keys")
+	}
+	if a.Quantize(grid) != b.Quantize(grid) {
+		t.Errorf("near-points should quantize to equal keys: %v vs %v",
+			a.Quantize(grid), b.Quantize(grid))
+	}
+	// usable directly as map keys for welding
+	pts := map[Vec2D]int{}
+	pts[a.Quantize(grid)]++
+	pts[b.Quantize(grid)]++
+	if len(pts) != 1 || pts[a.Quantize(grid)] != 2 {
+		t.Errorf("quantized map should weld coincident points: %v", pts)
+	}
+	// distinct points stay distinct
+	c := Vec2D{1.5, 2.0}
+	if a.Quantize(grid) == c.Quantize(grid) {
+		t.Errorf("distinct points should not weld")
+	}
+	// zero grid is a no-op
+	if a.Quantize(0) != a {
+		t.Errorf("zero grid should leave vector unchanged")
+	}
+}
+
+func TestMatrix2DTransformVectorToInt(t *testing.T) {
+	// translation must be excluded -- vectors are directions, not positions
+	a := Translate2D(100, 200).Scale(2, 3)
+	tx, ty := a.TransformVectorToInt(5, 5)
+	if tx != 10 || ty != 15 {
+		t.Errorf("TransformVectorToInt should exclude translation: got (%v, %v)", tx, ty)
+	}
+	// rounds to nearest rather than truncating
+	tx, ty = Scale2D(0.5, 0.5).TransformVectorToInt(3, 5)
+	if tx != 2 || ty != 3 {
+		t.Errorf("TransformVectorToInt should round: got (%v, %v)", tx, ty)
+	}
+	px, py := a.TransformPointToInt(5, 5)
+	if px == tx && py == ty {
+		t.Errorf("point transform should differ from vector transform under translation")
+	}
+}
+
+func TestMatrix2DSetStringEmpty(t *testing.T) {
+	id := Identity2D()
+	for _, str := range []string{"", "   ", "none"} {
+		a := Translate2D(5, 5)
+		if err := a.SetString(str); err != nil {
+			t.Errorf("SetString(%q): unexpected error: %v", str, err)
+		}
+		if a != id {
+			t.Errorf("SetString(%q): expected identity, got %v", str, a)
+		}
+	}
+}
+
+func TestVec2DMaxMinDim(t *testing.T) {
+	sq := Vec2D{5, 5}
+	if sq.MaxDim() != X || sq.MinDim() != Y {
+		t.Errorf("square should tie to X for max, Y for min: %v, %v", sq.MaxDim(), sq.MinDim())
+	}
+	wide := Vec2D{10, 5}
+	if wide.MaxDim() != X || wide.MinDim() != Y {
+		t.Errorf("wide: got max %v, min %v", wide.MaxDim(), wide.MinDim())
+	}
+	tall := Vec2D{5, 10}
+	if tall.MaxDim() != Y || tall.MinDim() != X {
+		t.Errorf("tall: got max %v, min %v", tall.MaxDim(), tall.MinDim())
+	}
+	if tall.MaxVal() != 10 || tall.MinVal() != 5 {
+		t.Errorf("tall vals: got max %v, min %v", tall.MaxVal(), tall.MinVal())
+	}
+}
+
+func TestVec2DArray(t *testing.T) {
+	v := Vec2D{1.5, -2.5}
+	if ar := v.Array(); ar != [2]float32{1.5, -2.5} {
+		t.Errorf("Array: got %v", ar)
+	}
+	if rt := Vec2DFromArray(v.Array()); rt != v {
+		t.Errorf("float32 array round-trip: expected %v, got %v", v, rt)
+	}
+	if ar := v.Array64(); ar != [2]float64{1.5, -2.5} {
+		t.Errorf("Array64: got %v", ar)
+	}
+	if rt := Vec2DFromArray64(v.Array64()); rt != v {
+		t.Errorf("float64 array round-trip: expected %v, got %v", v, rt)
+	}
+}
+
+func TestMatrix2DSetStringScale(t *testing.T) {
+	var a Matrix2D
+	if err := a.SetString("scale(2)"); err != nil {
+		t.Fatalf("scale(2): %v", err)
+	}
+	if a != Scale2D(2, 2) {
+		t.Errorf("scale(2): got %v", a)
+	}
+	if err := a.SetString("scale(2, 3)"); err != nil {
+		t.Fatalf("scale(2, 3): %v", err)
+	}
+	if a != Scale2D(2, 3) {
+		t.Errorf("scale(2, 3): got %v", a)
+	}
+	// scale about a center -- nonstandard 4-arg extension: center is fixed
+	if err := a.SetString("scale(2, 2, 10, 20)"); err != nil {
+		t.Fatalf("scale about center: %v", err)
+	}
+	if cp := a.TransformPointVec2D(Vec2D{10, 20}); cp != (Vec2D{10, 20}) {
+		t.Errorf("scale center should be fixed: got %v", cp)
+	}
+	if tp := a.TransformPointVec2D(Vec2D{11, 21}); tp != (Vec2D{12, 22}) {
+		t.Errorf("point near center should scale about it: got %v", tp)
+	}
+}
+
+func TestVec2DSetZero(t *testing.T) {
+	v := Vec2D{2, 3}
+	v.SetZero()
+	if !v.IsZero() {
+		t.Errorf("SetZero: expected zero vector, got %v", v)
+	}
+	v.SetVal(4)
+	if !v.IsEqualVal(4) {
+		t.Errorf("IsEqualVal: expected true for %v == 4", v)
+	}
+	v.Y = 5
+	if v.IsEqualVal(4) {
+		t.Errorf("IsEqualVal: expected false for %v == 4", v)
+	}
+}
+
+func TestVec2DToGeom(t *testing.T) {
+	v := Vec2D{2.2, 3.7}
+	sz := v.ToGeomSize()
+	if sz != (image.Point{3, 4}) { // always ceil -- never under-allocate
+		t.Errorf("ToGeomSize: expected (3, 4), got %v", sz)
+	}
+	ps := v.ToGeomPos()
+	if ps != (image.Point{2, 3}) { // always floor
+		t.Errorf("ToGeomPos: expected (2, 3), got %v", ps)
+	}
+}
+
+func TestMatrix2DJSON(t *testing.T) {
+	m := Identity2D().Translate(5, -3).Scale(2, 0.5).Rotate(math32.Pi / 4)
+	b, err := json.Marshal(m)
+	if err != nil {
+		t.Fatalf("Marshal: %v", err)
+	}
+	if b[0] != '"' || !strings.HasPrefix(string(b[1:]), "matrix(") {
+		t.Errorf("Marshal: expected quoted matrix(..) form, got %v", string(b))
+	}
+	var rt Matrix2D
+	if err := json.Unmarshal(b, &rt); err != nil {
+		t.Fatalf("Unmarshal: %v", err)
+	}
+	tol := float32(1.0e-4)
+	if math32.Abs(rt.XX-m.XX) > tol || math32.Abs(rt.YX-m.YX) > tol ||
+		math32.Abs(rt.XY-m.XY) > tol || math32.Abs(rt.YY-m.YY) > tol ||
+		math32.Abs(rt.X0-m.X0) > tol || math32.Abs(rt.Y0-m.Y0) > tol {
+		t.Errorf("round-trip: expected %v, got %v", m, rt)
+	}
+
+	// old six-field object form still unmarshals
+	var old Matrix2D
+	lgc := `{"XX":1,"YX":2,"XY":3,"YY":4,"X0":5,"Y0":6}`
+	if err := json.Unmarshal([]byte(lgc), &old); err != nil {
+		t.Fatalf("Unmarshal legacy: %v", err)
+	}
+	if old != (Matrix2D{1, 2, 3, 4, 5, 6}) {
+		t.Errorf("legacy form: expected {1 2 3 4 5 6}, got %v", old)
+	}
+}
+
+func TestMatrix2DExtractSkew(t *testing.T) {
+	tol := float32(1.0e-4)
+	skw := float32(0.3)
+	m := Identity2D().Skew(skw, 0)
+	if es := m.ExtractSkew(); math32.Abs(es-skw) > tol {
+		t.Errorf("pure skew: expected %v, got %v", skw, es)
+	}
+	// composed as rotate * skew * scale -- skew survives both
+	m = Identity2D().Rotate(math32.Pi/3).Skew(skw, 0).Scale(2, 0.5)
+	if es := m.ExtractSkew(); math32.Abs(es-skw) > tol {
+		t.Errorf("rotate+skew+scale: expected %v, got %v", skw, es)
+	}
+	// no skew: rotation and scale alone give zero
+	m = Identity2D().Rotate(0.5).Scale(3, 2)
+	if es := m.ExtractSkew(); math32.Abs(es) > tol {
+		t.Errorf("no skew: expected 0, got %v", es)
+	}
+}
+
+func TestVec2DRotateSinCos(t *testing.T) {
+	tol := float32(1.0e-6)
+	v := Vec2D{1, 0}
+	if r := v.Rotate(math32.Pi / 2); math32.Abs(r.X) > tol || math32.Abs(r.Y-1) > tol {
+		t.Errorf("Rotate quarter turn: got %v", r)
+	}
+	// RotateSinCos with a precomputed pair matches Rotate
+	ang := float32(0.7)
+	sin, cos := math32.Sin(ang), math32.Cos(ang)
+	pts := []Vec2D{{1, 0}, {0, 1}, {-2, 3}, {4.5, -1.25}}
+	for _, p := range pts {
+		rr := p.Rotate(ang)
+		rsc := p.RotateSinCos(sin, cos)
+		if math32.Abs(rr.X-rsc.X) > tol || math32.Abs(rr.Y-rsc.Y) > tol {
+			t.Errorf("RotateSinCos mismatch for %v: %v vs %v", p, rsc, rr)
+		}
+	}
+}
+
+func BenchmarkVec2DRotateSinCos(b *testing.B) {
+	pts := make([]Vec2D, 1000)
+	for i := range pts {
+		pts[i] = Vec2D{float32(i), float32(i % 7)}
+	}
+	ang := float32(0.3)
+	sin, cos := math32.Sin(ang), math32.Cos(ang)
+	b.ResetTimer()
+	for n := 0; n < b.N; n++ {
+		for i := range pts {
+			pts[i] = pts[i].RotateSinCos(sin, cos)
+		}
+	}
+}
+
+func TestVec2DApply(t *testing.T) {
+	v := Vec2D{2, -3}
+	if d := v.Apply(func(c float32) float32 { return 2 * c }); d != (Vec2D{4, -6}) {
+		t.Errorf("Apply double: got %v", d)
+	}
+	clamp := func(c float32) float32 { return Min32(Max32(c, 0), 1) }
+	if cl := (Vec2D{1.5, -0.25}).Apply(clamp); cl != (Vec2D{1, 0}) {
+		t.Errorf("Apply clamp: got %v", cl)
+	}
+}
+
+func TestGeom2DIntString(t *testing.T) {
+	gm := Geom2DInt{Pos: image.Point{3, -4}, Size: image.Point{100, 50}}
+	if s := gm.String(); s != "{Pos:(3,-4) Size:(100,50)}" {
+		t.Errorf("String: got %q", s)
+	}
+}
+
+func TestRectToRectMatrix(t *testing.T) {
+	// stretch: axes scale independently, corners map to corners
+	m := RectToRectMatrix(Vec2D{0, 0}, Vec2D{10, 10}, Vec2D{100, 50}, Vec2D{20, 40}, false)
+	if x, y := m.TransformPoint(0, 0); x != 100 || y != 50 {
+		t.Errorf("stretch min corner: got (%v, %v)", x, y)
+	}
+	if x, y := m.TransformPoint(10, 10); x != 120 || y != 90 {
+		t.Errorf("stretch max corner: got (%v, %v)", x, y)
+	}
+
+	// uniform: aspect preserved, centered within dst (viewBox Meet)
+	m = RectToRectMatrix(Vec2D{0, 0}, Vec2D{10, 10}, Vec2D{0, 0}, Vec2D{40, 20}, true)
+	if x, y := m.TransformPoint(0, 0); x != 10 || y != 0 {
+		t.Errorf("uniform min corner should center on X: got (%v, %v)", x, y)
+	}
+	if x, y := m.TransformPoint(10, 10); x != 30 || y != 20 {
+		t.Errorf("uniform max corner: got (%v, %v)", x, y)
+	}
+
+	// degenerate zero-size src: scale 1, pure translation
+	m = RectToRectMatrix(Vec2D{5, 5}, Vec2D{0, 0}, Vec2D{50, 60}, Vec2D{10, 10}, false)
+	if m.XX != 1 || m.YY != 1 {
+		t.Errorf("zero-size src should map with unit scale: %v", m)
+	}
+	if x, y := m.TransformPoint(5, 5); x != 50 || y != 60 {
+		t.Errorf("zero-size src position should map to dst position: got (%v, %v)", x, y)
+	}
+}
+
+func TestVec2DCompareDims(t *testing.T) {
+	lim := Vec2D{10, 10}
+	cases := []struct {
+		v      Vec2D
+		gx, gy bool
+	}{
+		{Vec2D{15, 15}, true, true}, // both exceed
+		{Vec2D{15, 5}, true, false}, // only X
+		{Vec2D{5, 15}, false, true}, // only Y
+		{Vec2D{5, 5}, false, false}, // neither
+	}
+	for _, c := range cases {
+		gd := c.v.GreaterDims(lim)
+		if gd.Has(X) != c.gx || gd.Has(Y) != c.gy {
+			t.Errorf("GreaterDims %v: got X %v, Y %v", c.v, gd.Has(X), gd.Has(Y))
+		}
+		// less is the complement here, except equal components
+		ld := c.v.LessDims(lim)
+		if ld.Has(X) != !c.gx || ld.Has(Y) != !c.gy {
+			t.Errorf("LessDims %v: got X %v, Y %v", c.v, ld.Has(X), ld.Has(Y))
+		}
+	}
+	// equal components are in neither set
+	eq := Vec2D{10, 10}
+	if eq.GreaterDims(lim) != 0 || eq.LessDims(lim) != 0 {
+		t.Errorf("equal components should be in neither set")
+	}
+}
+
+func TestClosestPointOnSegment(t *testing.T) {
+	a := Vec2D{0, 0}
+	b := Vec2D{10, 0}
+	// projection inside the segment
+	if cp := ClosestPointOnSegment(Vec2D{4, 5}, a, b); cp != (Vec2D{4, 0}) {
+		t.Errorf("inside projection: got %v", cp)
+	}
+	if tp := SegmentParam(Vec2D{4, 5}, a, b); tp != 0.4 {
+		t.Errorf("inside parameter: got %v", tp)
+	}
+	// beyond each endpoint: clamped
+	if cp := ClosestPointOnSegment(Vec2D{-3, 2}, a, b); cp != a {
+		t.Errorf("before start should clamp to a: got %v", cp)
+	}
+	if cp := ClosestPointOnSegment(Vec2D{15, -2}, a, b); cp != b {
+		t.Errorf("past end should clamp to b: got %v", cp)
+	}
+	if tp := SegmentParam(Vec2D{15, -2}, a, b); tp != 1 {
+		t.Errorf("past end parameter should clamp to 1: got %v", tp)
+	}
+	// degenerate zero-length segment
+	if cp := ClosestPointOnSegment(Vec2D{3, 3}, a, a); cp != a {
+		t.Errorf("degenerate segment should return its point: got %v", cp)
+	}
+}
+
+func TestVec2DFromPointDelta(t *testing.T) {
+	from := image.Point{3, 7}
+	to := image.Point{10, 2}
+	dv := Vec2DFromPointDelta(from, to)
+	if dv != (Vec2D{7, -5}) {
+		t.Errorf("delta: got %v", dv)
+	}
+	if Vec2DFromPointDelta(to, to) != Vec2DZero {
+		t.Errorf("zero delta should be Vec2DZero")
+	}
+}
+
+func TestMatrix2DSetStringOrigin(t *testing.T) {
+	tol := float32(1.0e-4)
+	org := Vec2D{10, 10}
+	var mo Matrix2D
+	if err := mo.SetStringOrigin("rotate(90)", org); err != nil {
+		t.Error(err)
+	}
+	// origin is a fixed point of the transform
+	ox, oy := mo.TransformPoint(org.X, org.Y)
+	if math32.Abs(ox-org.X) > tol || math32.Abs(oy-org.Y) > tol {
+		t.Errorf("origin should be fixed point: got %v, %v", ox, oy)
+	}
+	// (10,0) rotated 90 degrees about (10,10) lands at (20,10)
+	px, py := mo.TransformPoint(10, 0)
+	if math32.Abs(px-20) > tol || math32.Abs(py-10) > tol {
+		t.Errorf("rotate about origin: got %v, %v", px, py)
+	}
+	// vs rotation about zero
+	var mz Matrix2D
+	if err := mz.SetString("rotate(90)"); err != nil {
+		t.Error(err)
+	}
+	zx, zy := mz.TransformPoint(10, 0)
+	if math32.Abs(zx-0) > tol || math32.Abs(zy-10) > tol {
+		t.Errorf("rotate about zero: got %v, %v", zx, zy)
+	}
+	// zero origin is identical to SetString
+	var m0 Matrix2D
+	if err := m0.SetStringOrigin("rotate(90)", Vec2DZero); err != nil {
+		t.Error(err)
+	}
+	if m0 != mz {
+		t.Errorf("zero origin should match SetString: %v != %v", m0, mz)
+	}
+}
+
+func TestSegmentIntersect(t *testing.T) {
+	// crossing segments
+	pt, ok := SegmentIntersect(Vec2D{0, 0}, Vec2D{10, 10}, Vec2D{0, 10}, Vec2D{10, 0})
+	if !ok || pt != (Vec2D{5, 5}) {
+		t.Errorf("crossing: got %v, %v", pt, ok)
+	}
+	// parallel segments
+	if _, ok := SegmentIntersect(Vec2D{0, 0}, Vec2D{10, 0}, Vec2D{0, 5}, Vec2D{10, 5}); ok {
+		t.Errorf("parallel segments should not intersect")
+	}
+	// non-overlapping: intersection of the lines is outside the segments
+	if _, ok := SegmentIntersect(Vec2D{0, 0}, Vec2D{1, 1}, Vec2D{8, 10}, Vec2D{10, 8}); ok {
+		t.Errorf("non-overlapping segments should not intersect")
+	}
+	// segments sharing an endpoint
+	pt, ok = SegmentIntersect(Vec2D{0, 0}, Vec2D{5, 5}, Vec2D{5, 5}, Vec2D{10, 0})
+	if !ok || pt != (Vec2D{5, 5}) {
+		t.Errorf("shared endpoint: got %v, %v", pt, ok)
+	}
+}
+
+func TestNormalizeAngle(t *testing.T) {
+	tol := float32(1.0e-4)
+	tests := []struct {
+		in, wrap, sgn float32
+	}{
+		{0, 0, 0},
+		{math32.Pi / 2, math32.Pi / 2, math32.Pi / 2},
+		{math32.Pi, math32.Pi, math32.Pi},
+		{-math32.Pi / 2, 1.5 * math32.Pi, -math32.Pi / 2},
+		{4*math32.Pi + 1, 1, 1},
+		{-7.5 * math32.Pi, 0.5 * math32.Pi, 0.5 * math32.Pi},
+		{10*math32.Pi + 0.25, 0.25, 0.25},
+	}
+	for _, tst := range tests {
+		if got := NormalizeAngle(tst.in); math32.Abs(got-tst.wrap) > tol {
+			t.Errorf("NormalizeAngle(%v): got %v, expected %v", tst.in, got, tst.wrap)
+		}
+		if got := NormalizeAngleSigned(tst.in); math32.Abs(got-tst.sgn) > tol {
+			t.Errorf("NormalizeAngleSigned(%v): got %v, expected %v", tst.in, got, tst.sgn)
+		}
+	}
+}
+
+func TestVec2DSnapToNearest(t *testing.T) {
+	guides := []Vec2D{{0, 0}, {10, 0}, {10, 10}}
+	// near one candidate
+	pt, ok := Vec2D{9, 1}.SnapToNearest(guides, 3)
+	if !ok || pt != (Vec2D{10, 0}) {
+		t.Errorf("should snap to nearest guide: got %v, %v", pt, ok)
+	}
+	// equidistant between two: earlier candidate wins
+	pt, ok = Vec2D{5, 0}.SnapToNearest(guides, 6)
+	if !ok || pt != (Vec2D{0, 0}) {
+		t.Errorf("tie should go to earlier candidate: got %v, %v", pt, ok)
+	}
+	// beyond maxDist
+	if _, ok := (Vec2D{50, 50}).SnapToNearest(guides, 3); ok {
+		t.Errorf("no candidate within maxDist should return false")
+	}
+	// empty candidate list
+	if _, ok := (Vec2D{1, 1}).SnapToNearest(nil, 3); ok {
+		t.Errorf("empty candidates should return false")
+	}
+}
+
+func TestVec2DHypotNormalizeOr(t *testing.T) {
+	tol := float32(1.0e-6)
+	v := Vec2D{3, 4}
+	if v.Hypot() != 5 {
+		t.Errorf("Hypot: got %v", v.Hypot())
+	}
+	if v.Hypot() != v.Distance(Vec2DZero) {
+		t.Errorf("Hypot should equal distance from origin")
+	}
+	def := Vec2D{1, 0}
+	n := v.NormalizeOr(def)
+	if math32.Abs(n.X-0.6) > tol || math32.Abs(n.Y-0.8) > tol {
+		t.Errorf("NormalizeOr normal vector: got %v", n)
+	}
+	if Vec2DZero.NormalizeOr(def) != def {
+		t.Errorf("zero vector should return the default direction")
+	}
+}
+
+func TestMatrix2DFlip(t *testing.T) {
+	fx := Identity2D().FlipX(10)
+	// the axis line is fixed
+	if x, y := fx.TransformPoint(10, 7); x != 10 || y != 7 {
+		t.Errorf("FlipX axis should be fixed: got %v, %v", x, y)
+	}
+	// a point reflects to the other side
+	if x, y := fx.TransformPoint(13, 7); x != 7 || y != 7 {
+		t.Errorf("FlipX reflection: got %v, %v", x, y)
+	}
+	fy := Identity2D().FlipY(5)
+	if x, y := fy.TransformPoint(3, 5); x != 3 || y != 5 {
+		t.Errorf("FlipY axis should be fixed: got %v, %v", x, y)
+	}
+	if x, y := fy.TransformPoint(3, 9); x != 3 || y != 1 {
+		t.Errorf("FlipY reflection: got %v, %v", x, y)
+	}
+	// flipping twice about the same axis is the identity
+	if fx.FlipX(10) != Identity2D() {
+		t.Errorf("double FlipX should be identity: got %v", fx.FlipX(10))
+	}
+}
+
+func TestMatrix2DSetStringAdjacent(t *testing.T) {
+	want := Identity2D().Translate(10, 10).Rotate(Radians(45)).Scale(2, 2)
+	cmp := func(nm string, m Matrix2D) {
+		tol := float32(1.0e-4)
+		if math32.Abs(m.XX-want.XX) > tol || math32.Abs(m.YX-want.YX) > tol ||
+			math32.Abs(m.XY-want.XY) > tol || math32.Abs(m.YY-want.YY) > tol ||
+			math32.Abs(m.X0-want.X0) > tol || math32.Abs(m.Y0-want.Y0) > tol {
+			t.Errorf("%v: got %v, expected %v", nm, m, want)
+		}
+	}
+	// directly-adjacent transforms with no separators
+	var m Matrix2D
+	if err := m.SetString("translate(10,10)rotate(45)scale(2)"); err != nil {
+		t.Error(err)
+	}
+	cmp("adjacent", m)
+	// comma-separated commands
+	if err := m.SetString("translate(10,10), rotate(45), scale(2)"); err != nil {
+		t.Error(err)
+	}
+	cmp("comma-separated", m)
+	// semicolon-separated commands (as before)
+	if err := m.SetString("translate(10,10); rotate(45); scale(2)"); err != nil {
+		t.Error(err)
+	}
+	cmp("semicolon-separated", m)
+}
+
+func TestMatrix2DExtractScaleSigned(t *testing.T) {
+	tol := float32(1.0e-4)
+	chk := func(nm string, m Matrix2D, wx, wy float32) {
+		scx, scy := m.ExtractScaleSigned()
+		if math32.Abs(scx-wx) > tol || math32.Abs(scy-wy) > tol {
+			t.Errorf("%v: got %v, %v, expected %v, %v", nm, scx, scy, wx, wy)
+		}
+	}
+	chk("positive", Scale2D(2, 3), 2, 3)
+	chk("negative X", Scale2D(-2, 3), -2, 3)
+	chk("negative Y", Scale2D(2, -3), 2, -3)
+	// both negative: determinant is positive again (it is a 180 degree
+	// rotation) so scales report positive
+	chk("both negative", Scale2D(-2, -3), 2, 3)
+}
+
+func TestVec2DSwap(t *testing.T) {
+	v := Vec2D{3, 7}
+	if v.Swap() != (Vec2D{7, 3}) {
+		t.Errorf("Swap: got %v", v.Swap())
+	}
+	if v.Swap().Swap() != v {
+		t.Errorf("Swap twice should be identity: got %v", v.Swap().Swap())
+	}
+	v.SetSwap()
+	if v != (Vec2D{7, 3}) {
+		t.Errorf("SetSwap: got %v", v)
+	}
+}
+
+func TestEasing(t *testing.T) {
+	tol := float32(1.0e-6)
+	eases := map[string]func(float32) float32{
+		"EaseInQuad":     EaseInQuad,
+		"EaseOutQuad":    EaseOutQuad,
+		"EaseInOutCubic": EaseInOutCubic,
+	}
+	for nm, ef := range eases {
+		// exact endpoints
+		if ef(0) != 0 || math32.Abs(ef(1)-1) > tol {
+			t.Errorf("%v endpoints: got %v, %v", nm, ef(0), ef(1))
+		}
+		// strictly monotonic across [0,1]
+		prev := float32(0)
+		for i := 1; i <= 100; i++ {
+			tv := float32(i) / 100
+			ev := ef(tv)
+			if ev <= prev {
+				t.Errorf("%v not monotonic at t=%v: %v <= %v", nm, tv, ev, prev)
+				break
+			}
+			prev = ev
+		}
+	}
+	// midpoint character: in starts slow, out starts fast, in-out is exact
+	if EaseInQuad(0.5) >= 0.5 {
+		t.Errorf("EaseInQuad should be below linear at midpoint")
+	}
+	if EaseOutQuad(0.5) <= 0.5 {
+		t.Errorf("EaseOutQuad should be above linear at midpoint")
+	}
+	if EaseInOutCubic(0.5) != 0.5 {
+		t.Errorf("EaseInOutCubic midpoint should be 0.5: %v", EaseInOutCubic(0.5))
+	}
+
+	a := Vec2D{0, 10}
+	b := Vec2D{10, 20}
+	if a.Ease(b, 0, EaseInQuad) != a || a.Ease(b, 1, EaseInQuad) != b {
+		t.Errorf("Ease endpoints should be exact")
+	}
+	if a.Ease(b, 0.5, EaseInQuad) != a.Interpolate(b, 0.25) {
+		t.Errorf("Ease should shape progress by the easing function")
+	}
+	if a.Ease(b, 0.5, nil) != a.Interpolate(b, 0.5) {
+		t.Errorf("nil easing function should be linear")
+	}
+}
+
+func TestMatrix2DMapRect(t *testing.T) {
+	src := image.Rect(10, 20, 30, 60)     // 20 x 40
+	dst := image.Rect(100, 200, 140, 220) // 40 x 20
+	m := Matrix2DMapRect(src, dst)
+	corners := []struct {
+		src, dst image.Point
+	}{
+		{src.Min, dst.Min},
+		{image.Point{src.Max.X, src.Min.Y}, image.Point{dst.Max.X, dst.Min.Y}},
+		{src.Max, dst.Max},
+		{image.Point{src.Min.X, src.Max.Y}, image.Point{dst.Min.X, dst.Max.Y}},
+	}
+	for _, c := range corners {
+		got := m.TransformPointVec2D(NewVec2DFmPoint(c.src))
+		want := NewVec2DFmPoint(c.dst)
+		if got.Distance(want) > 1e-4 {
+			t.Errorf("corner %v should map to %v, got %v", c.src, c.dst, got)
+		}
+	}
+	// identity when src == dst
+	if m := Matrix2DMapRect(src, src); m != Identity2D() {
+		t.Errorf("mapping rect onto itself should be identity: %v", m)
+	}
+}
+
+func TestVec2DSnapPolar(t *testing.T) {
+	ctr := Vec2D{10, 10}
+	step := Radians(15)
+	// 20 degrees at radius 5 snaps to 15 degrees, radius unchanged
+	ang := Radians(20)
+	p := Vec2D{10 + 5*math32.Cos(ang), 10 + 5*math32.Sin(ang)}
+	got := p.SnapPolar(ctr, step, 0)
+	want := Vec2D{10 + 5*math32.Cos(Radians(15)), 10 + 5*math32.Sin(Radians(15))}
+	if got.Distance(want) > 1e-4 {
+		t.Errorf("angle should snap to 15 degrees: want %v, got %v", want, got)
+	}
+	// radius 12.7 snaps to 15 on a 5-unit grid, angle unchanged
+	got = Vec2D{10 + 12.7, 10}.SnapPolar(ctr, 0, 5)
+	want = Vec2D{25, 10}
+	if got.Distance(want) > 1e-4 {
+		t.Errorf("radius should snap to 15: want %v, got %v", want, got)
+	}
+	// both at once
+	p = Vec2D{10 + 12.7*math32.Cos(ang), 10 + 12.7*math32.Sin(ang)}
+	got = p.SnapPolar(ctr, step, 5)
+	want = Vec2D{10 + 15*math32.Cos(Radians(15)), 10 + 15*math32.Sin(Radians(15))}
+	if got.Distance(want) > 1e-4 {
+		t.Errorf("angle and radius should snap together: want %v, got %v", want, got)
+	}
+	// zero steps: unchanged
+	if got := p.SnapPolar(ctr, 0, 0); got.Distance(p) > 1e-4 {
+		t.Errorf("zero steps should leave point unchanged: %v vs %v", got, p)
+	}
+}
@@ -34,7 +34,7 @@ func (inm IconName) IsNil() bool {
 // IsValid tests whether the icon name is valid -- represents a non-nil icon
 // available in the current or default icon set
 func (inm IconName) IsValid() bool {
-	return TheIconMgr.IsValid(string(inm))
+	return TheIconMgr != nil && TheIconMgr.IsValid(string(inm))
 }
 
 // Icon is a wrapper around a child svg.Icon SVG element.  SVG should contain no
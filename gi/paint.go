@@ -948,6 +948,64 @@ func (pc *Paint) DrawEllipticalArcPath(rs *RenderState, cx, cy, ocx, ocy, pcx, p
 	return lx, ly
 }
 
+// ArcToBeziers converts an SVG elliptical arc from its endpoint
+// parameterization (start and end points, radii rx, ry, x-axis rotation in
+// degrees, large-arc and sweep flags) into cubic bezier segments, using the
+// endpoint-to-center conversion from the SVG spec (FindEllipseCenter) and the
+// same Maisonobe approximation as DrawEllipticalArcPath -- returns a
+// (ctrl1, ctrl2, end) point triple per segment, continuing from the given
+// start point -- nil for a degenerate arc (coincident endpoints or zero radius)
+func ArcToBeziers(start, end Vec2D, rx, ry, xRot float32, largeArc, sweep bool) []Vec2D {
+	if start == end || rx == 0 || ry == 0 {
+		return nil
+	}
+	rotX := xRot * math.Pi / 180
+	cx, cy := FindEllipseCenter(&rx, &ry, rotX, start.X, start.Y, end.X, end.Y, sweep, largeArc)
+	startAngle := math32.Atan2(start.Y-cy, start.X-cx) - rotX
+	endAngle := math32.Atan2(end.Y-cy, end.X-cx) - rotX
+	deltaTheta := endAngle - startAngle
+	arcBig := math32.Abs(deltaTheta) > math.Pi
+
+	etaStart := math32.Atan2(math32.Sin(startAngle)/ry, math32.Cos(startAngle)/rx)
+	etaEnd := math32.Atan2(math32.Sin(endAngle)/ry, math32.Cos(endAngle)/rx)
+	deltaEta := etaEnd - etaStart
+	if (arcBig && !largeArc) || (!arcBig && largeArc) {
+		if deltaEta < 0 {
+			deltaEta += math.Pi * 2
+		} else {
+			deltaEta -= math.Pi * 2
+		}
+	}
+	if deltaEta < 0 && sweep {
+		deltaEta += math.Pi * 2
+	} else if deltaEta >= 0 && !sweep {
+		deltaEta -= math.Pi * 2
+	}
+
+	segs := int(math32.Abs(deltaEta)/MaxDx) + 1
+	dEta := deltaEta / float32(segs)
+	tde := math32.Tan(dEta / 2)
+	alpha := math32.Sin(dEta) * (math32.Sqrt(4+3*tde*tde) - 1) / 3
+	lx, ly := start.X, start.Y
+	sinTheta, cosTheta := math32.Sin(rotX), math32.Cos(rotX)
+	ldx, ldy := ellipsePrime(rx, ry, sinTheta, cosTheta, etaStart, cx, cy)
+
+	pts := make([]Vec2D, 0, segs*3)
+	for i := 1; i <= segs; i++ {
+		eta := etaStart + dEta*float32(i)
+		var px, py float32
+		if i == segs {
+			px, py = end.X, end.Y // exact end point, no roundoff error
+		} else {
+			px, py = ellipsePointAt(rx, ry, sinTheta, cosTheta, eta, cx, cy)
+		}
+		dx, dy := ellipsePrime(rx, ry, sinTheta, cosTheta, eta, cx, cy)
+		pts = append(pts, Vec2D{lx + alpha*ldx, ly + alpha*ldy}, Vec2D{px - alpha*dx, py - alpha*dy}, Vec2D{px, py})
+		lx, ly, ldx, ldy = px, py, dx, dy
+	}
+	return pts
+}
+
 func (pc *Paint) DrawEllipse(rs *RenderState, x, y, rx, ry float32) {
 	pc.NewSubPath(rs)
 	pc.DrawEllipticalArc(rs, x, y, rx, ry, 0, 2*math32.Pi)
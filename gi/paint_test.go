@@ -0,0 +1,56 @@
+// Copyright (c) 2019, The GoKi Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package gi
+
+import (
+	"testing"
+
+	"github.com/chewxy/math32"
+)
+
+// cubicPointAt evaluates a cubic bezier from p0 with control points c1, c2 to
+// p1, at parameter t
+func cubicPointAt(p0, c1, c2, p1 Vec2D, t float32) Vec2D {
+	u := 1 - t
+	b0 := u * u * u
+	b1 := 3 * u * u * t
+	b2 := 3 * u * t * t
+	b3 := t * t * t
+	return p0.MulVal(b0).Add(c1.MulVal(b1)).Add(c2.MulVal(b2)).Add(p1.MulVal(b3))
+}
+
+func TestArcToBeziers(t *testing.T) {
+	// quarter circle of radius 10 about the origin, from (10,0) to (0,10)
+	r := float32(10)
+	start := Vec2D{r, 0}
+	end := Vec2D{0, r}
+	pts := ArcToBeziers(start, end, r, r, 0, false, true)
+	if len(pts) == 0 || len(pts)%3 != 0 {
+		t.Fatalf("expected non-empty triples, got %v points", len(pts))
+	}
+	if lst := pts[len(pts)-1]; lst != end {
+		t.Errorf("last point should be exact end: got %v", lst)
+	}
+	// every point along every segment should stay on the circle
+	tol := float32(0.01)
+	p0 := start
+	for i := 0; i < len(pts); i += 3 {
+		c1, c2, p1 := pts[i], pts[i+1], pts[i+2]
+		for _, tv := range []float32{0, 0.25, 0.5, 0.75, 1} {
+			bp := cubicPointAt(p0, c1, c2, p1, tv)
+			if math32.Abs(bp.Distance(Vec2DZero)-r) > tol {
+				t.Errorf("seg %v t=%v: radius %v, expected %v", i/3, tv, bp.Distance(Vec2DZero), r)
+			}
+		}
+		p0 = p1
+	}
+	// degenerate cases
+	if pts := ArcToBeziers(start, start, r, r, 0, false, true); pts != nil {
+		t.Errorf("coincident endpoints should give nil")
+	}
+	if pts := ArcToBeziers(start, end, 0, r, 0, false, true); pts != nil {
+		t.Errorf("zero radius should give nil")
+	}
+}
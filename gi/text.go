@@ -226,7 +226,7 @@ func (sr *SpanRender) AppendString(str string, face font.Face, clr, bg color.Col
 		return
 	}
 	ucfont := FontStyle{}
-	if oswin.TheApp.Platform() == oswin.MacOS {
+	if oswin.TheApp != nil && oswin.TheApp.Platform() == oswin.MacOS {
 		ucfont.Family = "Arial Unicode"
 	} else {
 		ucfont.Family = "Arial"
@@ -249,7 +249,7 @@ func (sr *SpanRender) AppendString(str string, face font.Face, clr, bg color.Col
 	for i := 1; i < sz; i++ { // optimize by setting rest to nil for same
 		rp := RuneRender{Deco: deco, BgColor: bg}
 		r := nwr[i]
-		if oswin.TheApp.Platform() == oswin.MacOS {
+		if oswin.TheApp != nil && oswin.TheApp.Platform() == oswin.MacOS {
 			if r > 0xFF && unicode.IsSymbol(r) {
 				if !lastUc {
 					rp.Face = ucfont.Face
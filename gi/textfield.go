@@ -45,6 +45,7 @@ type TextField struct {
 	Txt          string                  `json:"-" xml:"text" desc:"the last saved value of the text string being edited"`
 	Placeholder  string                  `json:"-" xml:"placeholder" desc:"text that is displayed when the field is empty, in a lower-contrast manner"`
 	ClearAct     bool                    `xml:"clear-act" desc:"add a clear action x at right side of edit, set from clear-act property (inherited) -- on by default"`
+	NoEcho       bool                    `xml:"no-echo" desc:"display bullet characters instead of the actual text, for passwords and other secrets -- set from no-echo property (inherited) -- the underlying text value is unaffected"`
 	CursorWidth  units.Value             `xml:"cursor-width" desc:"width of cursor -- set from cursor-width property (inherited)"`
 	Edited       bool                    `json:"-" xml:"-" desc:"true if the text has been edited relative to the original"`
 	EditTxt      []rune                  `json:"-" xml:"-" desc:"the live text string being edited, with latest modifications -- encoded as runes"`
@@ -1355,6 +1356,9 @@ func (tf *TextField) StyleTextField() {
 	if pv, ok := tf.PropInherit("clear-act", true, true); ok {
 		tf.ClearAct, _ = kit.ToBool(pv)
 	}
+	if pv, ok := tf.PropInherit("no-echo", true, true); ok {
+		tf.NoEcho, _ = kit.ToBool(pv)
+	}
 	tf.ConfigParts()
 	pr.End()
 }
@@ -1364,10 +1368,24 @@ func (tf *TextField) Style2D() {
 	tf.LayData.SetFromStyle(&tf.Sty.Layout) // also does reset
 }
 
+// DisplayRunes returns the runes to display for given slice of the edit
+// text: the text itself, or all bullet characters when NoEcho is set --
+// used for both sizing and rendering so cursor positions stay consistent
+func (tf *TextField) DisplayRunes(txt []rune) []rune {
+	if !tf.NoEcho {
+		return txt
+	}
+	msk := make([]rune, len(txt))
+	for i := range msk {
+		msk[i] = '•'
+	}
+	return msk
+}
+
 func (tf *TextField) UpdateRenderAll() bool {
 	st := &tf.Sty
 	st.Font.OpenFont(&st.UnContext)
-	tf.RenderAll.SetRunes(tf.EditTxt, &st.Font, &st.UnContext, &st.Text, true, 0, 0)
+	tf.RenderAll.SetRunes(tf.DisplayRunes(tf.EditTxt), &st.Font, &st.UnContext, &st.Text, true, 0, 0)
 	return true
 }
 
@@ -1442,7 +1460,7 @@ func (tf *TextField) Render2D() {
 		st := &tf.Sty
 		st.Font.OpenFont(&st.UnContext)
 		tf.RenderStdBox(st)
-		cur := tf.EditTxt[tf.StartPos:tf.EndPos]
+		cur := tf.DisplayRunes(tf.EditTxt[tf.StartPos:tf.EndPos])
 		tf.RenderSelect()
 		pos := tf.LayData.AllocPos.AddVal(st.BoxSpace())
 		if len(tf.EditTxt) == 0 && len(tf.Placeholder) > 0 {
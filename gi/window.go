@@ -318,8 +318,9 @@ func NewWindow2D(name, title string, width, height int, stdPixels bool) *Window
 // NewDialogWin creates a new dialog window with given internal handle name,
 // display name, and sizing (assumed to be in raw dots), without setting its
 // main viewport -- user should do win.AddChild(vp); win.Viewport = vp to set
-// their own viewport.
-func NewDialogWin(name, title string, width, height int, modal bool) *Window {
+// their own viewport.  fixedSize windows open at the computed size and set
+// resize hints so the user cannot resize them.
+func NewDialogWin(name, title string, width, height int, modal, fixedSize bool) *Window {
 	opts := &oswin.NewWindowOptions{
 		Title: title, Size: image.Point{width, height}, StdPixels: false,
 	}
@@ -327,6 +328,9 @@ func NewDialogWin(name, title string, width, height int, modal bool) *Window {
 	if modal {
 		opts.SetModal()
 	}
+	if fixedSize {
+		opts.SetFixedSize()
+	}
 	wgp := WinGeomPrefs.Pref(name, nil)
 	if wgp != nil {
 		opts.Size = wgp.Size
@@ -2462,6 +2466,21 @@ func (w *Window) SetFocus(k ki.Ki) bool {
 	return true
 }
 
+// FocusRoot returns the root node for focus traversal: the current popup if
+// there is one, else the active modal dialog if it is open within this window
+// -- so Tab / Shift+Tab cycle only within the dialog, wrapping at the ends,
+// instead of escaping to widgets behind it -- else the window viewport
+func (w *Window) FocusRoot() ki.Ki {
+	cpop := w.CurPopup()
+	if cpop != nil {
+		return cpop
+	}
+	if dlg := ActiveModalDialog(); dlg != nil && !dlg.IsSepWindow() && dlg.Win == w {
+		return dlg.This()
+	}
+	return w.Viewport.This()
+}
+
 // FocusNext sets the focus on the next item that can accept focus after the
 // given item (can be nil) -- returns true if a focus item found.
 func (w *Window) FocusNext(foc ki.Ki) bool {
@@ -2471,11 +2490,7 @@ func (w *Window) FocusNext(foc ki.Ki) bool {
 		focusNext = true
 	}
 
-	focRoot := w.Viewport.This()
-	cpop := w.CurPopup()
-	if cpop != nil {
-		focRoot = cpop
-	}
+	focRoot := w.FocusRoot()
 
 	for i := 0; i < 2; i++ {
 		focRoot.FuncDownMeFirst(0, w, func(k ki.Ki, level int, d interface{}) bool {
@@ -2554,11 +2569,7 @@ func (w *Window) FocusPrev(foc ki.Ki) bool {
 	gotFocus := false
 	var prevItem ki.Ki
 
-	focRoot := w.Viewport.This()
-	cpop := w.CurPopup()
-	if cpop != nil {
-		focRoot = cpop
-	}
+	focRoot := w.FocusRoot()
 
 	focRoot.FuncDownMeFirst(0, w, func(k ki.Ki, level int, d interface{}) bool {
 		if gotFocus {
@@ -2592,11 +2603,7 @@ func (w *Window) FocusPrev(foc ki.Ki) bool {
 func (w *Window) FocusLast() bool {
 	var lastItem ki.Ki
 
-	focRoot := w.Viewport.This()
-	cpop := w.CurPopup()
-	if cpop != nil {
-		focRoot = cpop
-	}
+	focRoot := w.FocusRoot()
 
 	focRoot.FuncDownMeFirst(0, w, func(k ki.Ki, level int, d interface{}) bool {
 		// todo: see about 3D guys
@@ -2619,11 +2626,7 @@ func (w *Window) FocusLast() bool {
 
 // ClearNonFocus clears the focus of any non-w.Focus item.
 func (w *Window) ClearNonFocus(foc ki.Ki) {
-	focRoot := w.Viewport.This()
-	cpop := w.CurPopup()
-	if cpop != nil {
-		focRoot = cpop
-	}
+	focRoot := w.FocusRoot()
 
 	updated := false
 	updt := false
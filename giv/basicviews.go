@@ -11,6 +11,7 @@ import (
 	"github.com/goki/gi/gi"
 	"github.com/goki/gi/units"
 	"github.com/goki/ki"
+	"github.com/goki/ki/bitflag"
 	"github.com/goki/ki/kit"
 )
 
@@ -712,6 +713,98 @@ func (vv *EnumValueView) ConfigWidget(widg gi.Node2D) {
 	vv.UpdateWidget()
 }
 
+////////////////////////////////////////////////////////////////////////////////////////
+//  BitFlagValueView
+
+// BitFlagValueView presents a row of checkboxes for a bitflag enum,
+// allowing multiple flags to be or-ed together
+type BitFlagValueView struct {
+	ValueViewBase
+}
+
+var KiT_BitFlagValueView = kit.Types.AddType(&BitFlagValueView{}, nil)
+
+func (vv *BitFlagValueView) WidgetType() reflect.Type {
+	vv.WidgetTyp = gi.KiT_Layout
+	return vv.WidgetTyp
+}
+
+func (vv *BitFlagValueView) EnumType() reflect.Type {
+	// derive type indirectly from the interface instead of directly from the value
+	// because that works for interface{} types as in property maps
+	typ := kit.NonPtrType(reflect.TypeOf(vv.Value.Interface()))
+	return typ
+}
+
+// SetEnumValueFromInt sets the bitflag value from the or-ed int64 of 1<<flag bits
+func (vv *BitFlagValueView) SetEnumValueFromInt(ival int64) bool {
+	typ := vv.EnumType()
+	eval := kit.EnumIfaceFromInt64(ival, typ)
+	return vv.SetValue(eval)
+}
+
+func (vv *BitFlagValueView) UpdateWidget() {
+	if vv.Widget == nil {
+		return
+	}
+	ly := vv.Widget.(*gi.Layout)
+	npv := kit.NonPtrValue(vv.Value)
+	bf, ok := kit.ToInt(npv.Interface())
+	if !ok {
+		return
+	}
+	evs := kit.Enums.TypeValues(vv.EnumType(), true)
+	for i := range evs {
+		if i >= len(ly.Kids) {
+			break
+		}
+		cbx := ly.KnownChild(i).Embed(gi.KiT_CheckBox).(*gi.CheckBox)
+		cbx.SetChecked(bitflag.Has(bf, int(evs[i].Value)))
+	}
+}
+
+func (vv *BitFlagValueView) ConfigWidget(widg gi.Node2D) {
+	vv.Widget = widg
+	ly := vv.Widget.(*gi.Layout)
+	ly.Lay = gi.LayoutHoriz
+	evs := kit.Enums.TypeValues(vv.EnumType(), true)
+	config := kit.TypeAndNameList{}
+	for _, ev := range evs {
+		config.Add(gi.KiT_CheckBox, ev.Name)
+	}
+	mods, updt := ly.ConfigChildren(config, false)
+	for i, ev := range evs {
+		cbx := ly.KnownChild(i).Embed(gi.KiT_CheckBox).(*gi.CheckBox)
+		cbx.SetText(ev.Name)
+		cbx.Tooltip, _ = vv.Tag("desc")
+		cbx.SetInactiveState(vv.This().(ValueView).IsInactive())
+		cbx.ButtonSig.ConnectOnly(vv.This(), func(recv, send ki.Ki, sig int64, data interface{}) {
+			if sig != int64(gi.ButtonToggled) {
+				return
+			}
+			vvv, _ := recv.Embed(KiT_BitFlagValueView).(*BitFlagValueView)
+			lyy := vvv.Widget.(*gi.Layout)
+			var bf int64
+			for ci, cev := range kit.Enums.TypeValues(vvv.EnumType(), true) {
+				if ci >= len(lyy.Kids) {
+					break
+				}
+				cb := lyy.KnownChild(ci).Embed(gi.KiT_CheckBox).(*gi.CheckBox)
+				if cb.IsChecked() {
+					bitflag.Set(&bf, int(cev.Value))
+				}
+			}
+			if vvv.SetEnumValueFromInt(bf) {
+				vvv.UpdateWidget()
+			}
+		})
+	}
+	if mods {
+		ly.UpdateEnd(updt)
+	}
+	vv.UpdateWidget()
+}
+
 ////////////////////////////////////////////////////////////////////////////////////////
 //  TypeValueView
 
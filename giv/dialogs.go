@@ -5,7 +5,9 @@
 package giv
 
 import (
+	"fmt"
 	"image"
+	"sync"
 
 	"github.com/goki/gi/gi"
 	"github.com/goki/gi/units"
@@ -396,6 +398,50 @@ func ArgViewDialog(avp *gi.Viewport2D, args []ArgData, opts DlgOpts, recv ki.Ki,
 	sv.SetInactiveState(false)
 	sv.SetArgs(args)
 
+	dlg.AcceptCheck = func() error { // per-arg validator props veto acceptance
+		return ArgsValidate(args)
+	}
+
+	if recv != nil && dlgFunc != nil {
+		dlg.DialogSig.Connect(recv, dlgFunc)
+	}
+	dlg.SetProp("min-width", units.NewValue(60, units.Em))
+	dlg.SetProp("min-height", units.NewValue(30, units.Em))
+	dlg.UpdateEndNoSig(true)
+	dlg.Open(0, 0, avp, nil)
+	return dlg
+}
+
+// TabDialogPage specifies one page of a TabDialog: the tab label and the
+// Build function called with the tab's content frame to populate it
+type TabDialogPage struct {
+	Label string                `desc:"tab label"`
+	Build func(frame *gi.Frame) `desc:"called with the tab's content frame to populate the page"`
+}
+
+// TabDialog opens a multi-page dialog with one tab per page, for
+// preferences-style dialogs with many sections -- each page's Build function
+// is called with its tab frame to populate the content, and the Ok / Cancel
+// buttons are shared across all pages.  Optionally connects to given signal
+// receiving object and function for dialog signals (nil to ignore).
+func TabDialog(avp *gi.Viewport2D, opts DlgOpts, pages []TabDialogPage, recv ki.Ki, dlgFunc ki.RecvFunc) *gi.Dialog {
+	dlg := gi.NewStdDialog(opts.ToGiOpts(), true, true)
+
+	frame := dlg.Frame()
+	_, prIdx := dlg.PromptWidget(frame)
+
+	tv := frame.InsertNewChild(gi.KiT_TabView, prIdx+1, "tabs").(*gi.TabView)
+	tv.Viewport = dlg.Embed(gi.KiT_Viewport2D).(*gi.Viewport2D)
+	for _, pg := range pages {
+		tf, _ := tv.AddNewTab(gi.KiT_Frame, pg.Label)
+		if pg.Build != nil {
+			pg.Build(tf.(*gi.Frame))
+		}
+	}
+	if len(pages) > 0 {
+		tv.SelectTabIndex(0)
+	}
+
 	if recv != nil && dlgFunc != nil {
 		dlg.DialogSig.Connect(recv, dlgFunc)
 	}
@@ -405,3 +451,55 @@ func ArgViewDialog(avp *gi.Viewport2D, args []ArgData, opts DlgOpts, recv ki.Ki,
 	dlg.Open(0, 0, avp, nil)
 	return dlg
 }
+
+// ProgressRun tracks an in-flight RunWithProgress operation.
+type ProgressRun struct {
+	Dlg      *gi.Dialog    `desc:"the progress dialog, closed when the work function returns"`
+	Err      error         `json:"-" xml:"-" desc:"the work function's error return -- valid after Done is closed"`
+	ErrShown bool          `desc:"whether the error dialog was shown for a non-nil Err"`
+	Done     chan struct{} `json:"-" xml:"-" desc:"closed when the work function has finished and the dialogs are resolved"`
+	Mu       sync.Mutex    `json:"-" xml:"-" view:"-" desc:"protects Frac and Status updates from the worker goroutine"`
+	Frac     float32       `desc:"last reported progress fraction (0..1)"`
+	Status   string        `desc:"last reported status message"`
+}
+
+// RunWithProgress opens a progress dialog with given title and runs the work
+// function on a separate goroutine, passing it a thread-safe report callback
+// that updates the progress fraction and status message shown in the dialog.
+// The dialog is closed when work returns, and if work returns an error, an
+// error dialog showing it is opened.  This is the common pattern around long
+// operations invoked from method views.  Returns immediately with a
+// ProgressRun tracking the operation -- wait on its Done channel for
+// completion.
+func RunWithProgress(avp *gi.Viewport2D, title string, work func(report func(frac float32, status string)) error) *ProgressRun {
+	pr := &ProgressRun{Done: make(chan struct{})}
+	dlg := gi.NewStdDialog(gi.DlgOpts{Title: title, Prompt: "Starting..."}, false, false)
+	dlg.UpdateEndNoSig(true)
+	pr.Dlg = dlg
+	if avp != nil && avp.Win != nil {
+		dlg.Open(0, 0, avp, nil)
+	}
+	report := func(frac float32, status string) {
+		pr.Mu.Lock()
+		pr.Frac = frac
+		pr.Status = status
+		pr.Mu.Unlock()
+		updt := dlg.UpdateStart()
+		if lbl, _ := dlg.PromptWidget(dlg.Frame()); lbl != nil {
+			lbl.Text = fmt.Sprintf("%v%%  %v", int(frac*100), status)
+		}
+		dlg.SetFullReRender()
+		dlg.UpdateEnd(updt)
+	}
+	go func() {
+		pr.Err = work(report)
+		dlg.Close()
+		if pr.Err != nil {
+			pr.ErrShown = true
+			gi.PromptDialog(avp, gi.DlgOpts{Title: "Error: " + title, Prompt: pr.Err.Error()},
+				true, false, nil, nil)
+		}
+		close(pr.Done)
+	}()
+	return pr
+}
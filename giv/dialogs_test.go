@@ -0,0 +1,89 @@
+// Copyright (c) 2019, The GoKi Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package giv
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/goki/gi/gi"
+)
+
+func TestRunWithProgress(t *testing.T) {
+	pr := RunWithProgress(nil, "Copying", func(report func(frac float32, status string)) error {
+		report(0.5, "halfway")
+		report(1, "done")
+		return fmt.Errorf("disk full")
+	})
+	<-pr.Done
+	if pr.Frac != 1 || pr.Status != "done" {
+		t.Errorf("progress not reported: frac = %v, status = %v", pr.Frac, pr.Status)
+	}
+	if pr.Err == nil || pr.Err.Error() != "disk full" {
+		t.Errorf("work error should be recorded: %v", pr.Err)
+	}
+	if !pr.ErrShown {
+		t.Errorf("error dialog path should be taken for a failing work function")
+	}
+	if pr.Dlg.State != gi.DialogCanceled && pr.Dlg.State != gi.DialogExists {
+		t.Errorf("progress dialog should be closed, state = %v", pr.Dlg.State)
+	}
+
+	// successful work: no error dialog
+	pr = RunWithProgress(nil, "Copying", func(report func(frac float32, status string)) error {
+		report(1, "done")
+		return nil
+	})
+	<-pr.Done
+	if pr.Err != nil || pr.ErrShown {
+		t.Errorf("successful work should not take the error path: %v", pr.Err)
+	}
+}
+
+func TestTabDialog(t *testing.T) {
+	vp := &gi.Viewport2D{}
+	vp.InitName(vp, "test-vp")
+	var built []string
+	pages := []TabDialogPage{
+		{Label: "General", Build: func(frame *gi.Frame) {
+			built = append(built, "General")
+			frame.AddNewChild(gi.KiT_Label, "general-lbl")
+		}},
+		{Label: "Advanced", Build: func(frame *gi.Frame) {
+			built = append(built, "Advanced")
+			frame.AddNewChild(gi.KiT_Label, "advanced-lbl")
+		}},
+	}
+	dlg := TabDialog(vp, DlgOpts{Title: "Preferences"}, pages, nil, nil)
+	if len(built) != 2 || built[0] != "General" || built[1] != "Advanced" {
+		t.Fatalf("both pages should be built in order: %v", built)
+	}
+	tvk, has := dlg.Frame().ChildByName("tabs", 0)
+	if !has {
+		t.Fatalf("dialog frame should contain the tab view")
+	}
+	tv := tvk.(*gi.TabView)
+	if tv.NTabs() != 2 {
+		t.Fatalf("tab view should have one tab per page: %v", tv.NTabs())
+	}
+	gfr, _, ok := tv.TabAtIndex(0)
+	if !ok || gfr.AsNode2D().Nm != "General" {
+		t.Errorf("first tab should be the General frame: %v", gfr)
+	}
+	if _, has := gfr.AsNode2D().ChildByName("general-lbl", 0); !has {
+		t.Errorf("General tab frame should have its built content")
+	}
+	afr, _, _ := tv.TabAtIndex(1)
+	if _, has := afr.AsNode2D().ChildByName("advanced-lbl", 0); !has {
+		t.Errorf("Advanced tab frame should have its built content")
+	}
+	// shared dialog-level buttons
+	if dlg.ButtonByName("ok") == nil || dlg.ButtonByName("cancel") == nil {
+		t.Errorf("tab dialog should have shared Ok / Cancel buttons")
+	}
+	if _, idx, ok := tv.CurTab(); !ok || idx != 0 {
+		t.Errorf("first tab should be selected initially: %v", idx)
+	}
+}
@@ -5,10 +5,14 @@
 package giv
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"reflect"
+	"sort"
 	"strings"
+	"sync"
+	"time"
 
 	"github.com/fatih/camelcase"
 
@@ -95,6 +99,33 @@ func MainMenuView(val interface{}, win *gi.Window, mbar *gi.MenuBar) bool {
 	return rval
 }
 
+// RebuildMainMenuView rebuilds the given MenuBar from the current "MainMenu"
+// type properties for given value element -- use when menu entries are
+// registered on the type at runtime (e.g., by plugins) after the menu was
+// first configured.  Existing menu actions are cleared and reconfigured in
+// place, including the special AppMenu / Edit / Window entries, which are
+// regenerated rather than duplicated.  Returns false if there is no main
+// menu defined for this type, or on errors.
+func RebuildMainMenuView(val interface{}, win *gi.Window, mbar *gi.MenuBar) bool {
+	if win == nil {
+		return false
+	}
+	if mbar == nil {
+		mbar = win.MainMenu
+	}
+	if mbar == nil {
+		return false
+	}
+	updt := mbar.UpdateStart()
+	for _, mbk := range mbar.Kids {
+		if ma, ok := mbk.Embed(gi.KiT_Action).(*gi.Action); ok {
+			ma.Menu = nil // prevent re-appending to existing menus
+		}
+	}
+	mbar.UpdateEnd(updt)
+	return MainMenuView(val, win, mbar)
+}
+
 // HasToolBarView returns true if given val has a ToolBar type property
 // registered -- call this to check before then calling ToolBarView.
 func HasToolBarView(val interface{}) bool {
@@ -132,6 +163,12 @@ func ToolBarView(val interface{}, vp *gi.Viewport2D, tb *gi.ToolBar) bool {
 
 	rval := true
 	for _, te := range tp {
+		if MethViewShowIn(te.Value) == "menu" { // menu-only: not on toolbar
+			continue
+		}
+		if !MethViewVisible(val, te.Value) {
+			continue
+		}
 		if strings.HasPrefix(te.Name, "sep-") {
 			sep := tb.AddNewChild(gi.KiT_Separator, te.Name).(*gi.Separator)
 			sep.Horiz = false
@@ -153,6 +190,64 @@ func ToolBarView(val interface{}, vp *gi.Viewport2D, tb *gi.ToolBar) bool {
 	return rval
 }
 
+// ToolBarViewOverflow collapses low-priority toolbar actions into an
+// overflow "…" action with a submenu, so that at most maxItems items remain
+// directly on the toolbar.  Actions opt in via an "overflow-priority" int
+// property in their ToolBar config -- lower priority values move to the
+// overflow menu first.  Call this after ToolBarView when available space is
+// constrained (e.g., from a resize handler).  Returns the overflow action,
+// or nil if nothing needed to move.
+func ToolBarViewOverflow(tb *gi.ToolBar, maxItems int) *gi.Action {
+	type ovfItem struct {
+		ac *gi.Action
+		pr int
+	}
+	var cand []ovfItem
+	nitm := 0
+	for _, k := range *tb.Children() {
+		ac, isAc := k.(*gi.Action)
+		if isAc && ac.Nm == "overflow" {
+			continue
+		}
+		nitm++
+		if !isAc {
+			continue
+		}
+		if pv, ok := ac.Prop("overflow-priority"); ok {
+			if pr, ok := kit.ToInt(pv); ok {
+				cand = append(cand, ovfItem{ac, int(pr)})
+			}
+		}
+	}
+	if nitm <= maxItems || len(cand) == 0 {
+		return nil
+	}
+	sort.SliceStable(cand, func(i, j int) bool {
+		return cand[i].pr < cand[j].pr
+	})
+	var ovf *gi.Action
+	if ovi, has := tb.ChildByName("overflow", 0); has {
+		ovf = ovi.(*gi.Action)
+	} else {
+		ovf = tb.AddNewChild(gi.KiT_Action, "overflow").(*gi.Action)
+		ovf.Text = "…"
+		ovf.Tooltip = "additional actions that do not fit on the toolbar"
+	}
+	for _, ov := range cand {
+		if nitm <= maxItems {
+			break
+		}
+		// set new parent before deleting so this is a move, not a delete --
+		// a NodeDeleted action would be skipped by event dispatch and
+		// updating, leaving the menu item rendered but unclickable
+		ov.ac.SetParent(ovf.This())
+		tb.DeleteChild(ov.ac, false)
+		ovf.Menu = append(ovf.Menu, ov.ac.This())
+		nitm--
+	}
+	return ovf
+}
+
 // CtxtMenuView configures a popup context menu according to the "CtxtMenu"
 // properties registered on the type for given value element, through the
 // kit.AddType method.  See https://github.com/goki/gi/wiki/Views for full
@@ -192,6 +287,9 @@ func CtxtMenuView(val interface{}, inactive bool, vp *gi.Viewport2D, menu *gi.Me
 			menu.AddSeparator(te.Name)
 			continue
 		}
+		if !MethViewVisible(val, te.Value) {
+			continue
+		}
 		ac := menu.AddAction(gi.ActOpts{Label: te.Name}, nil, nil)
 		rv := ActionsView(val, vtyp, vp, ac, te.Value)
 		if !rv {
@@ -373,15 +471,86 @@ var MethodViewCallMethsProp = "__MethViewCallMeths"
 //////////////////////////////////////////////////////////////////////////////////
 //    ActionsView
 
+// MethViewShowIn returns the "show-in" placement filter from given action
+// properties: "menu", "toolbar", or "both" (the default), so that one action
+// registration can be placed selectively by MainMenuView and ToolBarView
+func MethViewShowIn(pp interface{}) string {
+	if props, ok := pp.(ki.Props); ok {
+		if si, has := props["show-in"]; has {
+			return kit.ToString(si)
+		}
+	}
+	return "both"
+}
+
+// MethViewVisible evaluates any "visible-func" prop (a VisibleFunc) in given
+// method properties for overall object val -- actions whose visible-func
+// returns false are not created at all, unlike updtfunc which only
+// deactivates them -- true (visible) by default
+func MethViewVisible(val interface{}, pp interface{}) bool {
+	if props, ok := pp.(ki.Props); ok {
+		if vf, has := props["visible-func"]; has {
+			switch f := vf.(type) {
+			case VisibleFunc:
+				return f(val)
+			case func(it interface{}) bool:
+				return f(val)
+			}
+		}
+	}
+	return true
+}
+
 // ActionsView processes properties for parent action pa for overall object
 // val of given type -- could have a sub-menu of further actions or might just
-// be a single action
+// be a single action.  in a sub-menu PropSlice, entries named "label" and
+// "icon" set the display text / icon of the group header action itself,
+// instead of adding menu items.  entries with a "group-" name prefix whose
+// value is itself a PropSlice are rendered inline (not as a sub-menu), with a
+// separator automatically inserted between successive groups
 func ActionsView(val interface{}, vtyp reflect.Type, vp *gi.Viewport2D, pa *gi.Action, pp interface{}) bool {
 	pa.Text = strings.Replace(strings.Join(camelcase.Split(pa.Nm), " "), "  ", " ", -1)
 	rval := true
 	switch pv := pp.(type) {
 	case ki.PropSlice:
 		for _, mm := range pv {
+			if mm.Name == "label" { // group-level label for this (sub-menu) action
+				pa.Text = kit.ToString(mm.Value)
+				continue
+			}
+			if mm.Name == "icon" { // group-level icon for this (sub-menu) action
+				pa.Icon = gi.IconName(kit.ToString(mm.Value))
+				continue
+			}
+			if MethViewShowIn(mm.Value) == "toolbar" { // toolbar-only: not in menus
+				continue
+			}
+			if !MethViewVisible(val, mm.Value) {
+				continue
+			}
+			if strings.HasPrefix(mm.Name, "group-") {
+				if gsl, ok := mm.Value.(ki.PropSlice); ok {
+					if len(pa.Menu) > 0 {
+						pa.Menu.AddSeparator("sep-" + mm.Name)
+					}
+					for _, gm := range gsl {
+						if MethViewShowIn(gm.Value) == "toolbar" {
+							continue
+						}
+						if !MethViewVisible(val, gm.Value) {
+							continue
+						}
+						nac := &gi.Action{}
+						nac.InitName(nac, gm.Name)
+						nac.SetAsMenu()
+						pa.Menu = append(pa.Menu, nac.This().(gi.Node2D))
+						if !ActionsView(val, vtyp, vp, nac, gm.Value) {
+							rval = false
+						}
+					}
+					continue
+				}
+			}
 			if strings.HasPrefix(mm.Name, "sep-") {
 				pa.Menu.AddSeparator(mm.Name)
 			} else {
@@ -425,7 +594,7 @@ func ActionView(val interface{}, vtyp reflect.Type, vp *gi.Viewport2D, ac *gi.Ac
 	nometh := false // set to true if doesn't have an actual method to call, e.g., keyfun
 	for pk, _ := range props {
 		switch pk {
-		case "keyfun":
+		case "keyfun", "func":
 			nometh = true
 		}
 	}
@@ -444,9 +613,13 @@ func ActionView(val interface{}, vtyp reflect.Type, vp *gi.Viewport2D, ac *gi.Ac
 	}
 
 	rval := true
-	md := &MethViewData{Val: val, ValVal: valval, Vp: vp, Method: methNm, MethVal: methVal, MethTyp: methTyp}
+	md := &MethViewData{Val: val, ValVal: valval, Vp: vp, Method: methNm, MethVal: methVal, MethTyp: methTyp, Updt: &MethViewUpdtState{}}
 	ac.Data = md
 
+	if hasmeth && methTyp.Type.NumIn() > 1 && methTyp.Type.In(1) == ctxType {
+		bitflag.Set32((*int32)(&md.Flags), int(MethViewCtxArg))
+	}
+
 	if MethViewNoUpdateAfterProp(val) {
 		bitflag.Set32((*int32)(&md.Flags), int(MethViewNoUpdateAfter))
 	}
@@ -471,12 +644,32 @@ func ActionView(val interface{}, vtyp reflect.Type, vp *gi.Viewport2D, ac *gi.Ac
 			} else {
 				MethViewErr(vtyp, fmt.Sprintf("ActionView for Method: %v, shortcut-func must be of type ShortcutFunc", methNm))
 			}
+		case "shortcut-fun":
+			// like keyfun-based shortcut, but also accepts a string-valued
+			// KeyFun name, for property tables loaded from files
+			if kf, ok := pv.(gi.KeyFuns); ok {
+				ac.Shortcut = gi.ShortcutForFun(kf)
+			} else {
+				var kf gi.KeyFuns
+				if err := kit.Enums.SetAnyEnumIfaceFromString(&kf, kit.ToString(pv)); err != nil {
+					MethViewErr(vtyp, fmt.Sprintf("ActionView for Method: %v, shortcut-fun string %v not a valid KeyFun name", methNm, pv))
+				} else {
+					ac.Shortcut = gi.ShortcutForFun(kf)
+				}
+			}
 		case "keyfun":
 			if kf, ok := pv.(gi.KeyFuns); ok {
 				ac.Shortcut = gi.ShortcutForFun(kf)
 				md.KeyFun = kf
 				bitflag.Set32((*int32)(&md.Flags), int(MethViewKeyFun))
 			}
+		case "func":
+			if ff, ok := pv.(func()); ok {
+				md.Func = ff
+			} else {
+				MethViewErr(vtyp, fmt.Sprintf("ActionView for Action: %v, func must be of type func()", methNm))
+				rval = false
+			}
 		case "label":
 			ac.Text = kit.ToString(pv)
 		case "label-func":
@@ -489,18 +682,81 @@ func ActionView(val interface{}, vtyp reflect.Type, vp *gi.Viewport2D, ac *gi.Ac
 				MethViewErr(vtyp, fmt.Sprintf("ActionView for Method: %v, label-func must be of type LabelFunc", methNm))
 			}
 		case "icon":
-			ac.Icon = gi.IconName(kit.ToString(pv))
+			if ac.Icon == "" { // icon-func overrides static icon
+				ac.Icon = gi.IconName(kit.ToString(pv))
+			}
+		case "icon-func":
+			if sf, ok := pv.(IconFunc); ok {
+				md.IconFunc = sf
+			} else if sf, ok := pv.(func(it interface{}, act *gi.Action) gi.IconName); ok {
+				md.IconFunc = sf
+			} else {
+				MethViewErr(vtyp, fmt.Sprintf("ActionView for Method: %v, icon-func must be of type IconFunc", methNm))
+			}
+			if md.IconFunc != nil {
+				ac.Icon = md.IconFunc(md.Val, ac)
+				ac.UpdateFunc = MethViewUpdateFunc
+			}
 		case "desc":
 			md.Desc = kit.ToString(pv)
 			ac.Tooltip = md.Desc
+		case "show-in":
+			si := kit.ToString(pv)
+			if si != "menu" && si != "toolbar" && si != "both" {
+				MethViewErr(vtyp, fmt.Sprintf("ActionView for Method: %v, show-in must be menu, toolbar, or both", methNm))
+			}
+		case "overflow-priority":
+			if pr, ok := kit.ToInt(pv); ok {
+				ac.SetProp("overflow-priority", int(pr))
+			} else {
+				MethViewErr(vtyp, fmt.Sprintf("ActionView for Method: %v, overflow-priority must be an int", methNm))
+			}
 		case "confirm":
 			bitflag.Set32((*int32)(&md.Flags), int(MethViewConfirm))
+			if cp, ok := pv.(ki.Props); ok { // optional customization props
+				if ct, has := cp["confirm-text"]; has {
+					md.ConfirmText = kit.ToString(ct)
+				}
+				if ct, has := cp["cancel-text"]; has {
+					md.CancelText = kit.ToString(ct)
+				}
+				if ds, has := cp["destructive"]; has {
+					md.ConfirmDestructive, _ = kit.ToBool(ds)
+				}
+			}
+		case "confirm-prompt":
+			md.ConfirmPrompt = kit.ToString(pv)
+			bitflag.Set32((*int32)(&md.Flags), int(MethViewConfirm))
 		case "show-return":
 			bitflag.Set32((*int32)(&md.Flags), int(MethViewShowReturn))
+		case "view-return":
+			bitflag.Set32((*int32)(&md.Flags), int(MethViewViewReturn))
+		case "after-func":
+			if af, ok := pv.(AfterFunc); ok {
+				md.AfterFunc = af
+			} else if af, ok := pv.(func(it interface{}, results []reflect.Value)); ok {
+				md.AfterFunc = AfterFunc(af)
+			} else {
+				MethViewErr(vtyp, fmt.Sprintf("ActionView for Method: %v, after-func must be of type AfterFunc", methNm))
+			}
+		case "remember-args":
+			bitflag.Set32((*int32)(&md.Flags), int(MethViewRememberArgs))
 		case "no-update-after":
 			bitflag.Set32((*int32)(&md.Flags), int(MethViewNoUpdateAfter))
+		case "throttle-update":
+			bitflag.Set32((*int32)(&md.Flags), int(MethViewThrottleUpdate))
 		case "update-after": // if MethViewNoUpdateAfterProp was set above
 			bitflag.Clear32((*int32)(&md.Flags), int(MethViewNoUpdateAfter))
+		case "visible-func":
+			if vf, ok := pv.(VisibleFunc); ok {
+				md.VisibleFunc = vf
+				ac.UpdateFunc = MethViewUpdateFunc
+			} else if vf, ok := pv.(func(it interface{}) bool); ok {
+				md.VisibleFunc = VisibleFunc(vf)
+				ac.UpdateFunc = MethViewUpdateFunc
+			} else {
+				MethViewErr(vtyp, fmt.Sprintf("ActionView for Method: %v, visible-func must be of type VisibleFunc", methNm))
+			}
 		case "updtfunc":
 			if uf, ok := pv.(ActionUpdateFunc); ok {
 				md.UpdateFunc = uf
@@ -554,10 +810,17 @@ func ActionView(val interface{}, vtyp reflect.Type, vp *gi.Viewport2D, ac *gi.Ac
 	return true
 }
 
+// ctxType is the context.Context interface type, for detecting methods whose
+// first arg is a system-supplied cancelable context (MethViewCtxArg)
+var ctxType = reflect.TypeOf((*context.Context)(nil)).Elem()
+
 // ActionViewArgsValidate validates the Args properties relative to number of args on type
 func ActionViewArgsValidate(md *MethViewData, vtyp reflect.Type, meth reflect.Method, argprops ki.PropSlice) bool {
 	mtyp := meth.Type
 	narg := mtyp.NumIn()
+	if bitflag.Has32((int32)(md.Flags), int(MethViewCtxArg)) {
+		narg-- // context arg is supplied by the system, not in Args props
+	}
 	apsz := len(argprops)
 	if narg-1 != apsz {
 		MethViewErr(vtyp, fmt.Sprintf("Method: %v takes %v args (beyond the receiver), but Args properties only has %v", meth.Name, narg-1, apsz))
@@ -598,6 +861,25 @@ const (
 	// MethViewKeyFun means this action's only function is to emit the key fun
 	MethViewKeyFun
 
+	// MethViewRememberArgs means the last-entered arg values are cached by
+	// method name and used to pre-fill the arg prompt on the next call
+	MethViewRememberArgs
+
+	// MethViewCtxArg means the method's first arg is a context.Context that
+	// is supplied automatically rather than prompted for -- it is canceled
+	// if the user cancels the progress dialog shown while the method runs
+	MethViewCtxArg
+
+	// MethViewViewReturn opens the method's first return value in a view
+	// dialog appropriate for its type (view-return prop), instead of the
+	// plain text display of show-return
+	MethViewViewReturn
+
+	// MethViewThrottleUpdate coalesces the full render updates from rapid
+	// repeated calls of this action (throttle-update prop): calls within
+	// MethViewUpdateWindow trigger a single render when the window elapses
+	MethViewThrottleUpdate
+
 	MethViewFlagsN
 )
 
@@ -619,29 +901,102 @@ type ShortcutFunc func(it interface{}, act *gi.Action) key.Chord
 // first argument is the object on which the method is defined (receiver)
 type LabelFunc func(it interface{}, act *gi.Action) string
 
+// IconFunc is a function that returns an icon name to set an action's icon,
+// used in the MethView icon-func option for state-dependent icons (e.g.,
+// toggles) -- called on each update
+// first argument is the object on which the method is defined (receiver)
+type IconFunc func(it interface{}, act *gi.Action) gi.IconName
+
 // ActionUpdateFunc is a function that updates method active / inactive status
 // first argument is the object on which the method is defined (receiver)
 type ActionUpdateFunc func(it interface{}, act *gi.Action)
 
+// VisibleFunc is a function that reports whether a method-view action should
+// be shown at all for current state, used in the visible-func option --
+// unlike updtfunc, which only grays an action out, a false result means no
+// action is created at build time, and visibility is toggled on refresh
+// first argument is the object on which the method is defined (receiver)
+type VisibleFunc func(it interface{}) bool
+
+// AfterFunc is a function called after a method-view action's method has run,
+// with the method's return values, used in the MethView after-func option for
+// structured access to results (vs. the string display of show-return)
+// first argument is the object on which the method is defined (receiver)
+type AfterFunc func(it interface{}, results []reflect.Value)
+
+// MethViewUpdtState holds the coalescing state for throttle-update renders
+type MethViewUpdtState struct {
+	Mu      sync.Mutex `desc:"protects Pending from the update timer goroutine"`
+	Pending bool       `desc:"a coalesced render update is already scheduled"`
+}
+
 // MethViewData is set to the Action.Data field for all MethView actions,
 // containing info needed to actually call the Method on value Val.
 type MethViewData struct {
-	Val          interface{}
-	ValVal       reflect.Value
-	Vp           *gi.Viewport2D
-	Method       string
-	MethVal      reflect.Value
-	MethTyp      reflect.Method
-	ArgProps     ki.PropSlice     `desc:"names and other properties of args, in one-to-one with method args"`
-	SpecProps    ki.Props         `desc:"props for special action types, e.g., FileView"`
-	Desc         string           `desc:"prompt shown in arg dialog or confirm prompt dialog"`
-	UpdateFunc   ActionUpdateFunc `desc:"update function defined in properties -- called by our wrapper update function"`
-	SubMenuSlice interface{}      `desc:"value for submenu generation as a literal slice of items of appropriate type for method being called"`
-	SubMenuField string           `desc:"value for submenu generation as name of field on obj"`
-	SubMenuFunc  SubMenuFunc      `desc:"function that will generate submenu items, as []string slice"`
-	SubMenuVal   interface{}      `desc:"value that the user selected from submenu for this action -- this should be assigned to the first (only) arg of the method"`
-	KeyFun       gi.KeyFuns       `desc:"key function that we emit, if MethViewKeyFun type"`
-	Flags        MethViewFlags
+	Val                interface{}
+	ValVal             reflect.Value
+	Vp                 *gi.Viewport2D
+	Method             string
+	MethVal            reflect.Value
+	MethTyp            reflect.Method
+	ArgProps           ki.PropSlice       `desc:"names and other properties of args, in one-to-one with method args"`
+	SpecProps          ki.Props           `desc:"props for special action types, e.g., FileView"`
+	Desc               string             `desc:"prompt shown in arg dialog or confirm prompt dialog"`
+	ConfirmPrompt      string             `desc:"optional confirm prompt template (confirm-prompt prop) with {ArgName} placeholders replaced by the entered arg values -- implies confirm"`
+	ConfirmText        string             `desc:"optional label for the confirm dialog's Ok button (confirm-text in confirm props) -- e.g., Delete"`
+	CancelText         string             `desc:"optional label for the confirm dialog's Cancel button (cancel-text in confirm props) -- e.g., Keep"`
+	ConfirmDestructive bool               `desc:"style the confirm dialog's Ok button as destructive (destructive in confirm props) -- for irreversible actions"`
+	UpdateFunc         ActionUpdateFunc   `desc:"update function defined in properties -- called by our wrapper update function"`
+	SubMenuSlice       interface{}        `desc:"value for submenu generation as a literal slice of items of appropriate type for method being called"`
+	SubMenuField       string             `desc:"value for submenu generation as name of field on obj"`
+	SubMenuFunc        SubMenuFunc        `desc:"function that will generate submenu items, as []string slice"`
+	SubMenuVal         interface{}        `desc:"value that the user selected from submenu for this action -- this should be assigned to the first (only) arg of the method"`
+	KeyFun             gi.KeyFuns         `desc:"key function that we emit, if MethViewKeyFun type"`
+	IconFunc           IconFunc           `desc:"function that computes the action icon on each update (icon-func prop) -- overrides any static icon"`
+	VisibleFunc        VisibleFunc        `desc:"function that determines whether the action is visible at all (visible-func prop) -- checked at build time and on each update"`
+	Func               func()             `desc:"standalone function to call directly (func prop), instead of resolving a method by name -- for global commands such as Quit"`
+	AfterFunc          AfterFunc          `desc:"function called after the method runs, with the receiver and the method's reflect results (after-func prop)"`
+	ProgRun            *ProgressRun       `desc:"progress run tracking an executing context-taking method (MethViewCtxArg) -- canceling its dialog cancels the method's context"`
+	Updt               *MethViewUpdtState `view:"-" json:"-" xml:"-" desc:"coalescing state for throttle-update renders -- held by pointer so MethViewData stays copyable for submenu item actions"`
+	Flags              MethViewFlags
+}
+
+// MethViewUpdateWindow is the coalescing window for throttle-update actions:
+// repeated calls within this window trigger a single render when it elapses
+var MethViewUpdateWindow = 50 * time.Millisecond
+
+// MethViewRender is the function used to perform the full render update
+// after a method-view method runs -- a package variable so tests can
+// intercept render requests
+var MethViewRender = func(vp *gi.Viewport2D) {
+	vp.FullRender2DTree()
+}
+
+// MethViewUpdate performs (or schedules) the full render update after given
+// action's method has run: immediate by default, and coalesced within
+// MethViewUpdateWindow for throttle-update actions
+func MethViewUpdate(md *MethViewData) {
+	if !bitflag.Has32(int32(md.Flags), int(MethViewThrottleUpdate)) {
+		MethViewRender(md.Vp)
+		return
+	}
+	upd := md.Updt
+	if upd == nil { // directly-constructed MethViewData
+		upd = &MethViewUpdtState{}
+		md.Updt = upd
+	}
+	upd.Mu.Lock()
+	defer upd.Mu.Unlock()
+	if upd.Pending {
+		return
+	}
+	upd.Pending = true
+	time.AfterFunc(MethViewUpdateWindow, func() {
+		upd.Mu.Lock()
+		upd.Pending = false
+		upd.Mu.Unlock()
+		MethViewRender(md.Vp)
+	})
 }
 
 // MethViewCall is the receiver func for MethView actions that call a method
@@ -686,7 +1041,16 @@ func MethViewCall(recv, send ki.Ki, sig int64, data interface{}) {
 		md.Vp.This(), func(recv, send ki.Ki, sig int64, data interface{}) {
 			if sig == int64(gi.DialogAccepted) {
 				// ddlg := send.Embed(gi.KiT_Dialog).(*gi.Dialog)
-				MethViewCallMeth(md, args)
+				if bitflag.Has32(int32(md.Flags), int(MethViewConfirm)) {
+					gi.PromptDialog(md.Vp, MethViewConfirmOpts(md, ac.Text, MethViewConfirmPrompt(md, ads)),
+						true, true, md.Vp.This(), func(recv, send ki.Ki, sig int64, data interface{}) {
+							if sig == int64(gi.DialogAccepted) {
+								MethViewCallMeth(md, args)
+							}
+						})
+				} else {
+					MethViewCallMeth(md, args)
+				}
 			}
 		})
 }
@@ -702,7 +1066,7 @@ func MethViewCallNoArgPrompt(ac *gi.Action, md *MethViewData, args []reflect.Val
 		return
 	}
 	if bitflag.Has32(int32(md.Flags), int(MethViewConfirm)) {
-		gi.PromptDialog(md.Vp, gi.DlgOpts{Title: ac.Text, Prompt: md.Desc}, true, true,
+		gi.PromptDialog(md.Vp, MethViewConfirmOpts(md, ac.Text, md.Desc), true, true,
 			md.Vp.This(), func(recv, send ki.Ki, sig int64, data interface{}) {
 				if sig == int64(gi.DialogAccepted) {
 					MethViewCallMeth(md, args)
@@ -713,27 +1077,148 @@ func MethViewCallNoArgPrompt(ac *gi.Action, md *MethViewData, args []reflect.Val
 	}
 }
 
+// MethViewLastArgVals caches the last-entered arg values, keyed by method
+// name, for methods with the MethViewRememberArgs flag (remember-args prop)
+var MethViewLastArgVals = map[string][]interface{}{}
+
 // MethViewCallMeth calls the method with given args, and processes the
 // results as specified in the MethViewData.
 func MethViewCallMeth(md *MethViewData, args []reflect.Value) {
-	rv := md.MethVal.Call(args)
+	if bitflag.Has32(int32(md.Flags), int(MethViewRememberArgs)) {
+		avals := make([]interface{}, len(args))
+		for i, av := range args {
+			avals[i] = av.Interface()
+		}
+		MethViewLastArgVals[md.Method] = avals
+	}
+	var rv []reflect.Value
+	if md.Func != nil { // standalone function bound via func prop -- no method, no args
+		md.Func()
+	} else if bitflag.Has32(int32(md.Flags), int(MethViewCtxArg)) {
+		MethViewCallMethCtx(md, args)
+		return
+	} else {
+		rv = md.MethVal.Call(args)
+	}
+	if md.AfterFunc != nil {
+		md.AfterFunc(md.Val, rv)
+	}
 	if !bitflag.Has32(int32(md.Flags), int(MethViewNoUpdateAfter)) {
-		md.Vp.FullRender2DTree() // always update after all methods -- almost always want that
+		MethViewUpdate(md) // always update after all methods -- almost always want that
 	}
-	if bitflag.Has32(int32(md.Flags), int(MethViewShowReturn)) {
+	if len(rv) > 0 && bitflag.Has32(int32(md.Flags), int(MethViewViewReturn)) {
+		MethViewViewReturnDialog(md, rv[0].Interface())
+	} else if rv != nil && bitflag.Has32(int32(md.Flags), int(MethViewShowReturn)) {
 		gi.PromptDialog(md.Vp, gi.DlgOpts{Title: md.Method + " Result", Prompt: rv[0].String()}, true, false, nil, nil)
 	}
 }
 
+// MethViewViewReturnDialog opens given method return value in a view dialog
+// appropriate for its type (view-return prop): a TableView for a slice of
+// structs, SliceView for other slices, MapView for maps, and a StructView
+// otherwise -- returns the dialog, or nil for a nil value
+func MethViewViewReturnDialog(md *MethViewData, val interface{}) *gi.Dialog {
+	if kit.IfaceIsNil(val) {
+		return nil
+	}
+	opts := DlgOpts{Title: md.Method + " Result"}
+	typ := kit.NonPtrType(reflect.TypeOf(val))
+	switch typ.Kind() {
+	case reflect.Slice:
+		if kit.NonPtrType(typ.Elem()).Kind() == reflect.Struct {
+			return TableViewDialog(md.Vp, val, opts, nil, nil, nil)
+		}
+		return SliceViewDialog(md.Vp, val, opts, nil, nil, nil)
+	case reflect.Map:
+		return MapViewDialog(md.Vp, val, opts, nil, nil)
+	default:
+		return StructViewDialog(md.Vp, val, opts, nil, nil)
+	}
+}
+
+// MethViewCallMethCtx calls a context-taking method (MethViewCtxArg) under a
+// progress dialog via RunWithProgress, prepending a context that is canceled
+// when the user cancels the dialog.  The method runs asynchronously, so
+// return values are not processed, except a trailing error return, which is
+// reported via the after-dialog error reporting of RunWithProgress.
+func MethViewCallMethCtx(md *MethViewData, args []reflect.Value) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cargs := make([]reflect.Value, 0, len(args)+1)
+	cargs = append(cargs, reflect.ValueOf(ctx))
+	cargs = append(cargs, args...)
+	pr := RunWithProgress(md.Vp, md.Method, func(report func(frac float32, status string)) error {
+		defer cancel()
+		rv := md.MethVal.Call(cargs)
+		if len(rv) > 0 {
+			if err, ok := rv[len(rv)-1].Interface().(error); ok {
+				return err
+			}
+		}
+		return nil
+	})
+	pr.Dlg.DialogSig.Connect(pr.Dlg.This(), func(recv, send ki.Ki, sig int64, data interface{}) {
+		if sig == int64(gi.DialogCanceled) {
+			cancel()
+		}
+	})
+	md.ProgRun = pr
+}
+
+// ArgValidatorFunc is a function that validates a single arg value prior to
+// accepting the arg dialog, used in the MethView validator option -- returns
+// an error describing the problem, or nil if the value is acceptable
+type ArgValidatorFunc func(val interface{}) error
+
 // ArgData contains the relevant data for each arg, including the
 // reflect.Value, name, optional description, and default value
 type ArgData struct {
-	Val     reflect.Value
-	Name    string
-	Desc    string
-	View    ValueView
-	Default interface{}
-	Flags   ArgDataFlags
+	Val       reflect.Value
+	Name      string
+	Desc      string
+	View      ValueView
+	Default   interface{}
+	Validator ArgValidatorFunc `desc:"optional per-arg validation function run on dialog accept -- a non-nil error vetoes acceptance"`
+	Flags     ArgDataFlags
+}
+
+// MethViewConfirmPrompt returns the confirm prompt for given method data and
+// resolved args: the confirm-prompt template with each {ArgName} placeholder
+// replaced by that arg's entered value, so the user sees exactly what will
+// happen -- falls back on Desc if no template is set
+func MethViewConfirmPrompt(md *MethViewData, ads []ArgData) string {
+	if md.ConfirmPrompt == "" {
+		return md.Desc
+	}
+	prompt := md.ConfirmPrompt
+	for i := range ads {
+		ad := &ads[i]
+		vstr := fmt.Sprintf("%v", ad.Val.Elem().Interface())
+		prompt = strings.Replace(prompt, "{"+ad.Name+"}", vstr, -1)
+	}
+	return prompt
+}
+
+// MethViewConfirmOpts returns the DlgOpts for given method data's confirm
+// prompt dialog, with title and prompt as given, applying any button label
+// and destructive styling customization from the confirm props
+func MethViewConfirmOpts(md *MethViewData, title, prompt string) gi.DlgOpts {
+	return gi.DlgOpts{Title: title, Prompt: prompt, OkText: md.ConfirmText,
+		CancelText: md.CancelText, Destructive: md.ConfirmDestructive}
+}
+
+// ArgsValidate runs any Validator functions on given args against their
+// current values, returning the first error, or nil if all args pass
+func ArgsValidate(ads []ArgData) error {
+	for i := range ads {
+		ad := &ads[i]
+		if ad.Validator == nil {
+			continue
+		}
+		if err := ad.Validator(ad.Val.Elem().Interface()); err != nil {
+			return fmt.Errorf("%v: %v", ad.Name, err)
+		}
+	}
+	return nil
 }
 
 // ArgDataFlags define bitflags for method view action options
@@ -772,7 +1257,11 @@ func (ad *ArgData) HasValSet() bool {
 // user (minus any cases with value: set directly)
 func MethViewArgData(md *MethViewData) (ads []ArgData, args []reflect.Value, nprompt int, ok bool) {
 	mtyp := md.MethTyp.Type
-	narg := mtyp.NumIn() - 1
+	argoff := 1 // skip the receiver
+	if bitflag.Has32((int32)(md.Flags), int(MethViewCtxArg)) {
+		argoff++ // context arg is supplied at call time, not prompted
+	}
+	narg := mtyp.NumIn() - argoff
 	ads = make([]ArgData, narg)
 	args = make([]reflect.Value, narg)
 	nprompt = 0
@@ -780,7 +1269,7 @@ func MethViewArgData(md *MethViewData) (ads []ArgData, args []reflect.Value, npr
 
 	for ai := 0; ai < narg; ai++ {
 		ad := &ads[ai]
-		atyp := mtyp.In(1 + ai)
+		atyp := mtyp.In(argoff + ai)
 		av := reflect.New(atyp)
 		ad.Val = av
 		args[ai] = av.Elem()
@@ -789,6 +1278,15 @@ func MethViewArgData(md *MethViewData) (ads []ArgData, args []reflect.Value, npr
 		ad.Name = aps.Name
 
 		ad.View = ToValueView(ad.Val.Interface(), "")
+		if kit.NonPtrType(atyp).Kind() == reflect.Struct {
+			// struct args edit much better inline in the arg dialog than
+			// behind the opaque edit button of the default struct view
+			if _, opaque := ad.View.(*StructValueView); opaque {
+				vv := &StructInlineValueView{}
+				vv.Init(vv)
+				ad.View = vv
+			}
+		}
 		ad.View.SetStandaloneValue(ad.Val)
 		ad.View.SetName(ad.Name)
 		nprompt++ // assume prompt
@@ -828,11 +1326,25 @@ func MethViewArgData(md *MethViewData) (ads []ArgData, args []reflect.Value, npr
 							ad.SetHasDef()
 						}
 					}
+				case "validator":
+					if vf, ok := pv.(ArgValidatorFunc); ok {
+						ad.Validator = vf
+					} else if vf, ok := pv.(func(val interface{}) error); ok {
+						ad.Validator = vf
+					} else {
+						MethViewErr(md.ValVal.Type(), fmt.Sprintf("Method: %v arg: %v validator must be of type ArgValidatorFunc", md.Method, ad.Name))
+					}
 				default:
 					ad.View.SetTag(pk, kit.ToString(pv))
 				}
 			}
 		}
+		if !ad.HasValSet() && bitflag.Has32((int32)(md.Flags), int(MethViewRememberArgs)) {
+			if lvals, has := MethViewLastArgVals[md.Method]; has && ai < len(lvals) {
+				ad.Default = lvals[ai] // overrides any static default
+				ad.SetHasDef()
+			}
+		}
 		if ad.HasDef() {
 			ad.View.SetValue(ad.Default)
 		}
@@ -886,6 +1398,16 @@ func MethViewUpdateFunc(act *gi.Action) {
 	if md.UpdateFunc != nil && md.Val != nil {
 		md.UpdateFunc(md.Val, act)
 	}
+	if md.IconFunc != nil && md.Val != nil {
+		act.Icon = md.IconFunc(md.Val, act)
+	}
+	if md.VisibleFunc != nil && md.Val != nil {
+		if md.VisibleFunc(md.Val) {
+			act.ClearInvisible()
+		} else {
+			act.SetInvisible()
+		}
+	}
 }
 
 // MethViewSubMenuFunc is a MakeMenuFunc for items that have submenus
@@ -0,0 +1,907 @@
+// Copyright (c) 2019, The GoKi Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package giv
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"reflect"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/goki/gi/gi"
+	"github.com/goki/ki"
+	"github.com/goki/ki/bitflag"
+	"github.com/goki/ki/kit"
+)
+
+// TestMain registers a font path so the builtin Go fonts get loaded -- label
+// and button configuration in headless tests needs a usable font face, and
+// the font library only loads fonts (including the builtins) once it has at
+// least one path
+func TestMain(m *testing.M) {
+	gi.FontLibrary.InitFontPaths(os.TempDir())
+	os.Exit(m.Run())
+}
+
+type methViewTestObj struct {
+	Val    int
+	Flags  MethViewFlags
+	Secret string
+	CtxErr error
+}
+
+func (mv *methViewTestObj) SetVal(val int) {
+	mv.Val = val
+}
+
+func (mv *methViewTestObj) Bump() {
+	mv.Val++
+}
+
+func (mv *methViewTestObj) SetFlags(flags MethViewFlags) {
+	mv.Flags = flags
+}
+
+func (mv *methViewTestObj) WaitCtx(ctx context.Context) {
+	<-ctx.Done()
+	mv.CtxErr = ctx.Err()
+}
+
+// newMethViewTestData makes a MethViewData for methViewTestObj.SetVal with
+// remember-args behavior and no gui updating (no viewport in tests)
+func newMethViewTestData(obj *methViewTestObj) *MethViewData {
+	vval := reflect.ValueOf(obj)
+	mtyp, _ := reflect.TypeOf(obj).MethodByName("SetVal")
+	md := &MethViewData{
+		Val:      obj,
+		ValVal:   vval,
+		Method:   "SetVal",
+		MethVal:  vval.MethodByName("SetVal"),
+		MethTyp:  mtyp,
+		ArgProps: ki.PropSlice{{Name: "val", Value: ki.BlankProp{}}},
+	}
+	bitflag.Set32((*int32)(&md.Flags), int(MethViewRememberArgs))
+	bitflag.Set32((*int32)(&md.Flags), int(MethViewNoUpdateAfter))
+	return md
+}
+
+func TestMethViewRememberArgs(t *testing.T) {
+	delete(MethViewLastArgVals, "SetVal")
+	obj := &methViewTestObj{}
+	md := newMethViewTestData(obj)
+
+	ads, args, nprompt, ok := MethViewArgData(md)
+	if !ok || nprompt != 1 {
+		t.Fatalf("MethViewArgData failed: ok = %v, nprompt = %v", ok, nprompt)
+	}
+	if ads[0].HasDef() {
+		t.Errorf("first call should have no default value")
+	}
+	args[0].SetInt(42) // simulate user entering a value
+	MethViewCallMeth(md, args)
+	if obj.Val != 42 {
+		t.Errorf("method not called with entered value: %v", obj.Val)
+	}
+
+	// second prompt should pre-fill with the first run's entered value
+	ads, _, _, ok = MethViewArgData(md)
+	if !ok {
+		t.Fatalf("MethViewArgData failed on second call")
+	}
+	if !ads[0].HasDef() {
+		t.Fatalf("second call should have remembered default")
+	}
+	if dv, isInt := ads[0].Default.(int); !isInt || dv != 42 {
+		t.Errorf("remembered default: expected 42, got %v", ads[0].Default)
+	}
+}
+
+func TestMethViewShortcutFun(t *testing.T) {
+	if gi.ActiveKeyMap == nil {
+		gi.SetActiveKeyMapName(gi.DefaultKeyMap)
+	}
+	obj := &methViewTestObj{}
+	vp := &gi.Viewport2D{}
+	vp.InitName(vp, "test-vp")
+	ac := &gi.Action{}
+	ac.InitName(ac, "SetVal")
+	props := ki.Props{
+		"shortcut-fun": "KeyFunAbort", // string-named, as loaded from a file
+		"Args": ki.PropSlice{
+			{Name: "val", Value: ki.BlankProp{}},
+		},
+	}
+	if !ActionView(obj, reflect.TypeOf(obj), vp, ac, props) {
+		t.Fatalf("ActionView failed")
+	}
+	exp := gi.ShortcutForFun(gi.KeyFunAbort)
+	if exp == "" || ac.Shortcut != exp {
+		t.Errorf("shortcut-fun: expected %v, got %v", exp, ac.Shortcut)
+	}
+}
+
+func TestMethViewArgValidator(t *testing.T) {
+	obj := &methViewTestObj{}
+	vval := reflect.ValueOf(obj)
+	mtyp, _ := reflect.TypeOf(obj).MethodByName("SetVal")
+	md := &MethViewData{
+		Val:     obj,
+		ValVal:  vval,
+		Method:  "SetVal",
+		MethVal: vval.MethodByName("SetVal"),
+		MethTyp: mtyp,
+		ArgProps: ki.PropSlice{{Name: "val", Value: ki.Props{
+			"validator": func(val interface{}) error {
+				if val.(int) < 0 {
+					return fmt.Errorf("must be non-negative")
+				}
+				return nil
+			},
+		}}},
+	}
+	ads, args, _, ok := MethViewArgData(md)
+	if !ok {
+		t.Fatalf("MethViewArgData failed")
+	}
+	if ads[0].Validator == nil {
+		t.Fatalf("validator prop not picked up")
+	}
+	args[0].SetInt(-5)
+	if err := ArgsValidate(ads); err == nil {
+		t.Errorf("negative value should fail validation")
+	}
+	args[0].SetInt(5)
+	if err := ArgsValidate(ads); err != nil {
+		t.Errorf("non-negative value should pass validation: %v", err)
+	}
+}
+
+func TestMethViewConfirmPrompt(t *testing.T) {
+	obj := &methViewTestObj{}
+	vp := &gi.Viewport2D{}
+	vp.InitName(vp, "test-vp")
+	ac := &gi.Action{}
+	ac.InitName(ac, "SetVal")
+	props := ki.Props{
+		"confirm-prompt": "Set the value to {val}?",
+		"Args": ki.PropSlice{
+			{Name: "val", Value: ki.BlankProp{}},
+		},
+	}
+	if !ActionView(obj, reflect.TypeOf(obj), vp, ac, props) {
+		t.Fatalf("ActionView failed")
+	}
+	md := ac.Data.(*MethViewData)
+	if md.ConfirmPrompt == "" || !bitflag.Has32(int32(md.Flags), int(MethViewConfirm)) {
+		t.Fatalf("confirm-prompt should set template and confirm flag")
+	}
+	ads, args, _, ok := MethViewArgData(md)
+	if !ok {
+		t.Fatalf("MethViewArgData failed")
+	}
+	args[0].SetInt(42) // simulate the user entering a value in the arg dialog
+	prompt := MethViewConfirmPrompt(md, ads)
+	if prompt != "Set the value to 42?" {
+		t.Errorf("confirm prompt should interpolate entered args: %q", prompt)
+	}
+	// no template: falls back on Desc
+	md.ConfirmPrompt = ""
+	md.Desc = "plain desc"
+	if MethViewConfirmPrompt(md, ads) != "plain desc" {
+		t.Errorf("without template, confirm prompt should be Desc")
+	}
+}
+
+func TestMethViewConfirmCustom(t *testing.T) {
+	obj := &methViewTestObj{}
+	vp := &gi.Viewport2D{}
+	vp.InitName(vp, "test-vp")
+	ac := &gi.Action{}
+	ac.InitName(ac, "SetVal")
+	props := ki.Props{
+		"confirm": ki.Props{
+			"confirm-text": "Delete",
+			"cancel-text":  "Keep",
+			"destructive":  true,
+		},
+		"Args": ki.PropSlice{
+			{Name: "val", Value: ki.BlankProp{}},
+		},
+	}
+	if !ActionView(obj, reflect.TypeOf(obj), vp, ac, props) {
+		t.Fatalf("ActionView failed")
+	}
+	md := ac.Data.(*MethViewData)
+	if !bitflag.Has32(int32(md.Flags), int(MethViewConfirm)) {
+		t.Fatalf("confirm props should set confirm flag")
+	}
+	if md.ConfirmText != "Delete" || md.CancelText != "Keep" || !md.ConfirmDestructive {
+		t.Errorf("confirm props should set button customization: %q, %q, %v",
+			md.ConfirmText, md.CancelText, md.ConfirmDestructive)
+	}
+	opts := MethViewConfirmOpts(md, "Delete Item", "Really delete?")
+	if opts.OkText != "Delete" || opts.CancelText != "Keep" || !opts.Destructive {
+		t.Errorf("confirm opts should carry customization: %+v", opts)
+	}
+	// plain bool confirm: generic buttons
+	md2 := newMethViewTestData(obj)
+	opts2 := MethViewConfirmOpts(md2, "SetVal", "")
+	if opts2.OkText != "" || opts2.CancelText != "" || opts2.Destructive {
+		t.Errorf("plain confirm should leave generic buttons: %+v", opts2)
+	}
+}
+
+func TestMethViewFuncAction(t *testing.T) {
+	obj := &methViewTestObj{}
+	vp := &gi.Viewport2D{}
+	vp.InitName(vp, "test-vp")
+	ac := &gi.Action{}
+	ac.InitName(ac, "Quit") // no such method on obj -- bound to func instead
+	called := false
+	props := ki.Props{
+		"func":            func() { called = true },
+		"no-update-after": true,
+	}
+	if !ActionView(obj, reflect.TypeOf(obj), vp, ac, props) {
+		t.Fatalf("ActionView failed for func-bound action")
+	}
+	md, ok := ac.Data.(*MethViewData)
+	if !ok || md.Func == nil {
+		t.Fatalf("action data should have Func set")
+	}
+	MethViewCallMeth(md, nil)
+	if !called {
+		t.Errorf("func-bound action did not call the function")
+	}
+}
+
+type methViewToggleObj struct {
+	On bool
+}
+
+func (mv *methViewToggleObj) Toggle() {
+	mv.On = !mv.On
+}
+
+func TestMethViewIconFunc(t *testing.T) {
+	obj := &methViewToggleObj{}
+	vp := &gi.Viewport2D{}
+	vp.InitName(vp, "test-vp")
+	ac := &gi.Action{}
+	ac.InitName(ac, "Toggle")
+	props := ki.Props{
+		"icon": "close", // static fallback -- overridden by icon-func
+		"icon-func": func(it interface{}, act *gi.Action) gi.IconName {
+			if it.(*methViewToggleObj).On {
+				return "checked-box"
+			}
+			return "unchecked-box"
+		},
+	}
+	if !ActionView(obj, reflect.TypeOf(obj), vp, ac, props) {
+		t.Fatalf("ActionView failed")
+	}
+	if ac.Icon != "unchecked-box" {
+		t.Errorf("initial icon should reflect off state, got %v", ac.Icon)
+	}
+	obj.Toggle()
+	MethViewUpdateFunc(ac)
+	if ac.Icon != "checked-box" {
+		t.Errorf("icon should update to on state, got %v", ac.Icon)
+	}
+}
+
+func TestToolBarViewOverflow(t *testing.T) {
+	obj := &methViewTestObj{}
+	vp := &gi.Viewport2D{}
+	vp.InitName(vp, "test-vp")
+	tb := &gi.ToolBar{}
+	tb.InitName(tb, "toolbar")
+
+	names := []string{"SetVal", "Bump", "SetFlags"}
+	prios := []interface{}{nil, 1, 2} // SetVal never overflows
+	for i, nm := range names {
+		ac := tb.AddNewChild(gi.KiT_Action, nm).(*gi.Action)
+		props := ki.Props{"no-update-after": true}
+		if prios[i] != nil {
+			props["overflow-priority"] = prios[i]
+		}
+		if !ActionView(obj, reflect.TypeOf(obj), vp, ac, props) {
+			t.Fatalf("ActionView failed for %v", nm)
+		}
+	}
+
+	// plenty of space: nothing moves
+	if ovf := ToolBarViewOverflow(tb, 3); ovf != nil {
+		t.Errorf("no overflow should be created when everything fits")
+	}
+
+	// constrained: lowest-priority flagged action moves first
+	ovf := ToolBarViewOverflow(tb, 2)
+	if ovf == nil {
+		t.Fatalf("overflow action should be created when constrained")
+	}
+	if len(ovf.Menu) != 1 {
+		t.Fatalf("overflow menu should have 1 entry, got %v", len(ovf.Menu))
+	}
+	if ovf.Menu[0].Name() != "Bump" {
+		t.Errorf("lowest-priority action should move first, got %v", ovf.Menu[0].Name())
+	}
+	if _, has := tb.ChildByName("Bump", 0); has {
+		t.Errorf("moved action should no longer be on the toolbar")
+	}
+	// the move must not flag the action as deleted -- deleted receivers are
+	// dropped by event dispatch, making the menu item unclickable
+	mac := ovf.Menu[0].Embed(gi.KiT_Action).(*gi.Action)
+	if mac.IsDeleted() {
+		t.Fatalf("moved action should not be flagged as deleted")
+	}
+	mac.Trigger()
+	if obj.Val != 1 {
+		t.Errorf("moved action should still fire its method: Val = %v", obj.Val)
+	}
+	// unflagged action stays even under further constraint
+	ovf = ToolBarViewOverflow(tb, 1)
+	if ovf == nil || len(ovf.Menu) != 2 {
+		t.Fatalf("second constraint should move SetFlags too: %v", ovf)
+	}
+	if _, has := tb.ChildByName("SetVal", 0); !has {
+		t.Errorf("unflagged action should never move to overflow")
+	}
+}
+
+type methViewMenuObj struct {
+	Val int
+}
+
+func (mv *methViewMenuObj) DoThing() {
+	mv.Val++
+}
+
+func (mv *methViewMenuObj) DoOther() {
+	mv.Val--
+}
+
+var methViewMenuProps = ki.Props{
+	"MainMenu": ki.PropSlice{
+		{Name: "File", Value: ki.PropSlice{
+			{Name: "DoThing", Value: ki.BlankProp{}},
+		}},
+	},
+}
+
+var kiT_MethViewMenuObj = kit.Types.AddType(&methViewMenuObj{}, methViewMenuProps)
+
+func TestRebuildMainMenuView(t *testing.T) {
+	obj := &methViewMenuObj{}
+	win := &gi.Window{}
+	win.InitName(win, "test-win")
+	vp := &gi.Viewport2D{}
+	vp.InitName(vp, "test-vp")
+	win.Viewport = vp
+	mbar := &gi.MenuBar{}
+	mbar.InitName(mbar, "main-menu")
+
+	if !MainMenuView(obj, win, mbar) {
+		t.Fatalf("MainMenuView failed")
+	}
+	fileAct := mbar.KnownChildByName("File", 0).(*gi.Action)
+	if len(fileAct.Menu) != 1 {
+		t.Fatalf("File menu should have 1 entry, got %v", len(fileAct.Menu))
+	}
+
+	// a plugin registers another entry at runtime, then rebuilds
+	mm := methViewMenuProps["MainMenu"].(ki.PropSlice)
+	mm[0].Value = append(mm[0].Value.(ki.PropSlice),
+		ki.PropStruct{Name: "DoOther", Value: ki.BlankProp{}})
+	if !RebuildMainMenuView(obj, win, mbar) {
+		t.Fatalf("RebuildMainMenuView failed")
+	}
+	fileAct = mbar.KnownChildByName("File", 0).(*gi.Action)
+	if len(fileAct.Menu) != 2 {
+		t.Errorf("rebuilt File menu should have 2 entries without duplicates, got %v", len(fileAct.Menu))
+	}
+}
+
+type methViewTestOpts struct {
+	Name    string
+	Width   int
+	Height  int
+	Depth   int
+	Scale   float32
+	Visible bool
+	Fill    bool // > StructInlineLen fields, so default view would be opaque
+}
+
+func (mv *methViewTestObj) SetOpts(opts methViewTestOpts) {
+	mv.Val = opts.Width
+}
+
+func TestMethViewStructArg(t *testing.T) {
+	obj := &methViewTestObj{}
+	vval := reflect.ValueOf(obj)
+	mtyp, _ := reflect.TypeOf(obj).MethodByName("SetOpts")
+	md := &MethViewData{
+		Val:      obj,
+		ValVal:   vval,
+		Method:   "SetOpts",
+		MethVal:  vval.MethodByName("SetOpts"),
+		MethTyp:  mtyp,
+		ArgProps: ki.PropSlice{{Name: "opts", Value: ki.BlankProp{}}},
+	}
+	ads, _, _, ok := MethViewArgData(md)
+	if !ok {
+		t.Fatalf("MethViewArgData failed")
+	}
+	if _, inline := ads[0].View.(*StructInlineValueView); !inline {
+		t.Errorf("struct arg should get an inline struct view, got %T", ads[0].View)
+	}
+}
+
+func TestMethViewBitFlagArg(t *testing.T) {
+	obj := &methViewTestObj{}
+	vval := reflect.ValueOf(obj)
+	mtyp, _ := reflect.TypeOf(obj).MethodByName("SetFlags")
+	md := &MethViewData{
+		Val:      obj,
+		ValVal:   vval,
+		Method:   "SetFlags",
+		MethVal:  vval.MethodByName("SetFlags"),
+		MethTyp:  mtyp,
+		ArgProps: ki.PropSlice{{Name: "flags", Value: ki.BlankProp{}}},
+	}
+	bitflag.Set32((*int32)(&md.Flags), int(MethViewNoUpdateAfter))
+
+	ads, args, _, ok := MethViewArgData(md)
+	if !ok {
+		t.Fatalf("MethViewArgData failed")
+	}
+	if _, isBf := ads[0].View.(*BitFlagValueView); !isBf {
+		t.Fatalf("bitflag arg should get a BitFlagValueView, got %T", ads[0].View)
+	}
+	// simulate checking two flags in the view -- value is the or of the bits
+	var sel int64
+	bitflag.Set(&sel, int(MethViewConfirm), int(MethViewShowReturn))
+	bfv := ads[0].View.(*BitFlagValueView)
+	if !bfv.SetEnumValueFromInt(sel) {
+		t.Fatalf("SetEnumValueFromInt failed")
+	}
+	MethViewCallMeth(md, args)
+	exp := MethViewFlags(sel)
+	if obj.Flags != exp {
+		t.Errorf("method should receive or'd flags: expected %v, got %v", exp, obj.Flags)
+	}
+}
+
+func TestMethViewCtxArg(t *testing.T) {
+	obj := &methViewTestObj{}
+	vp := &gi.Viewport2D{}
+	vp.InitName(vp, "test-vp")
+	ac := &gi.Action{}
+	ac.InitName(ac, "WaitCtx")
+	props := ki.Props{
+		"no-update-after": true,
+	}
+	if !ActionView(obj, reflect.TypeOf(obj), vp, ac, props) {
+		t.Fatalf("ActionView failed")
+	}
+	md := ac.Data.(*MethViewData)
+	if !bitflag.Has32(int32(md.Flags), int(MethViewCtxArg)) {
+		t.Fatalf("context first arg should set MethViewCtxArg")
+	}
+	// the context arg is supplied by the system, not prompted
+	_, args, nprompt, ok := MethViewArgData(md)
+	if !ok || len(args) != 0 || nprompt != 0 {
+		t.Fatalf("context arg should not be promptable: args = %v, nprompt = %v", len(args), nprompt)
+	}
+	MethViewCallMeth(md, args)
+	pr := md.ProgRun
+	if pr == nil {
+		t.Fatalf("context method should run under a progress dialog")
+	}
+	pr.Dlg.Cancel() // user cancels -- should cancel the method's context
+	select {
+	case <-pr.Done:
+	case <-time.After(2 * time.Second):
+		t.Fatalf("canceling the dialog should cancel the context and finish the method")
+	}
+	if obj.CtxErr != context.Canceled {
+		t.Errorf("method should see canceled context: %v", obj.CtxErr)
+	}
+}
+
+func (mv *methViewTestObj) FailCtx(ctx context.Context) error {
+	return fmt.Errorf("ctx method failed")
+}
+
+func TestMethViewCtxArgError(t *testing.T) {
+	obj := &methViewTestObj{}
+	vp := &gi.Viewport2D{}
+	vp.InitName(vp, "test-vp")
+	ac := &gi.Action{}
+	ac.InitName(ac, "FailCtx")
+	props := ki.Props{
+		"no-update-after": true,
+	}
+	if !ActionView(obj, reflect.TypeOf(obj), vp, ac, props) {
+		t.Fatalf("ActionView failed")
+	}
+	md := ac.Data.(*MethViewData)
+	_, args, _, ok := MethViewArgData(md)
+	if !ok {
+		t.Fatalf("MethViewArgData failed")
+	}
+	MethViewCallMeth(md, args)
+	pr := md.ProgRun
+	if pr == nil {
+		t.Fatalf("context method should run under a progress dialog")
+	}
+	select {
+	case <-pr.Done:
+	case <-time.After(2 * time.Second):
+		t.Fatalf("method should finish")
+	}
+	if pr.Err == nil || pr.Err.Error() != "ctx method failed" {
+		t.Errorf("trailing error return should be reported: %v", pr.Err)
+	}
+}
+
+func (mv *methViewTestObj) Sum(a, b int) (int, error) {
+	return a + b, nil
+}
+
+func TestMethViewAfterFunc(t *testing.T) {
+	obj := &methViewTestObj{}
+	vp := &gi.Viewport2D{}
+	vp.InitName(vp, "test-vp")
+	ac := &gi.Action{}
+	ac.InitName(ac, "Sum")
+	var gotVal interface{}
+	var gotResults []reflect.Value
+	props := ki.Props{
+		"no-update-after": true,
+		"after-func": func(it interface{}, results []reflect.Value) {
+			gotVal = it
+			gotResults = results
+		},
+		"Args": ki.PropSlice{
+			{Name: "a", Value: ki.BlankProp{}},
+			{Name: "b", Value: ki.BlankProp{}},
+		},
+	}
+	if !ActionView(obj, reflect.TypeOf(obj), vp, ac, props) {
+		t.Fatalf("ActionView failed")
+	}
+	md := ac.Data.(*MethViewData)
+	if md.AfterFunc == nil {
+		t.Fatalf("after-func prop not picked up")
+	}
+	_, args, _, ok := MethViewArgData(md)
+	if !ok {
+		t.Fatalf("MethViewArgData failed")
+	}
+	args[0].SetInt(2)
+	args[1].SetInt(3)
+	MethViewCallMeth(md, args)
+	if gotVal != obj {
+		t.Errorf("after-func should receive the receiver, got %v", gotVal)
+	}
+	if len(gotResults) != 2 {
+		t.Fatalf("after-func should receive both results, got %v", len(gotResults))
+	}
+	if gotResults[0].Int() != 5 {
+		t.Errorf("first result should be 5, got %v", gotResults[0].Int())
+	}
+	if !gotResults[1].IsNil() {
+		t.Errorf("second result should be nil error, got %v", gotResults[1])
+	}
+}
+
+type methViewResult struct {
+	Name  string
+	Score int
+}
+
+func (mv *methViewTestObj) MakeResult() *methViewResult {
+	return &methViewResult{Name: "made", Score: 7}
+}
+
+func TestMethViewViewReturn(t *testing.T) {
+	obj := &methViewTestObj{}
+	vp := &gi.Viewport2D{}
+	vp.InitName(vp, "test-vp")
+	ac := &gi.Action{}
+	ac.InitName(ac, "MakeResult")
+	props := ki.Props{
+		"view-return":     true,
+		"no-update-after": true,
+	}
+	if !ActionView(obj, reflect.TypeOf(obj), vp, ac, props) {
+		t.Fatalf("ActionView failed")
+	}
+	md := ac.Data.(*MethViewData)
+	if !bitflag.Has32(int32(md.Flags), int(MethViewViewReturn)) {
+		t.Fatalf("view-return prop should set flag")
+	}
+	res := obj.MakeResult()
+	dlg := MethViewViewReturnDialog(md, res)
+	if dlg == nil {
+		t.Fatalf("struct pointer return should produce a dialog")
+	}
+	sv, _ := dlg.Frame().ChildByName("struct-view", 0)
+	if sv == nil {
+		t.Fatalf("dialog should contain a struct view")
+	}
+	if svv, ok := sv.(*StructView); !ok || svv.Struct != res {
+		t.Errorf("struct view should be editing the returned value")
+	}
+	// nil return value: no dialog
+	if MethViewViewReturnDialog(md, nil) != nil {
+		t.Errorf("nil return should not produce a dialog")
+	}
+}
+
+type methViewShowInObj struct {
+	Shown  bool
+	Hidden bool
+}
+
+func (mv *methViewShowInObj) ShowIt() { mv.Shown = true }
+func (mv *methViewShowInObj) HideIt() { mv.Hidden = true }
+
+var KiT_methViewShowInObj = kit.Types.AddType(&methViewShowInObj{}, ki.Props{
+	"ToolBar": ki.PropSlice{
+		{Name: "ShowIt", Value: ki.Props{"no-update-after": true}},
+		{Name: "HideIt", Value: ki.Props{"show-in": "menu", "no-update-after": true}},
+	},
+})
+
+func TestMethViewShowIn(t *testing.T) {
+	obj := &methViewShowInObj{}
+	vp := &gi.Viewport2D{}
+	vp.InitName(vp, "test-vp")
+	tb := &gi.ToolBar{}
+	tb.InitName(tb, "toolbar")
+	if !ToolBarView(obj, vp, tb) {
+		t.Fatalf("ToolBarView failed")
+	}
+	if _, has := tb.ChildByName("ShowIt", 0); !has {
+		t.Errorf("default (both) action should be on the toolbar")
+	}
+	if _, has := tb.ChildByName("HideIt", 0); has {
+		t.Errorf("menu-only action should be skipped by ToolBarView")
+	}
+
+	// and toolbar-only actions are skipped when building menus
+	pa := &gi.Action{}
+	pa.InitName(pa, "File")
+	ps := ki.PropSlice{
+		{Name: "ShowIt", Value: ki.Props{"show-in": "toolbar", "no-update-after": true}},
+		{Name: "HideIt", Value: ki.Props{"no-update-after": true}},
+	}
+	if !ActionsView(obj, reflect.TypeOf(obj), vp, pa, ps) {
+		t.Fatalf("ActionsView failed")
+	}
+	if len(pa.Menu) != 1 {
+		t.Fatalf("toolbar-only action should be skipped in menus: %v items", len(pa.Menu))
+	}
+	if pa.Menu[0].Name() != "HideIt" {
+		t.Errorf("remaining menu item should be HideIt: %v", pa.Menu[0].Name())
+	}
+}
+
+func TestMethViewGroupLabel(t *testing.T) {
+	obj := &methViewShowInObj{}
+	vp := &gi.Viewport2D{}
+	vp.InitName(vp, "test-vp")
+	pa := &gi.Action{}
+	pa.InitName(pa, "Edit")
+	ps := ki.PropSlice{
+		{Name: "ShowGroup", Value: ki.PropSlice{
+			{Name: "label", Value: "Show Stuff"},
+			{Name: "icon", Value: "edit"},
+			{Name: "ShowIt", Value: ki.Props{"no-update-after": true}},
+			{Name: "HideIt", Value: ki.Props{"no-update-after": true}},
+		}},
+	}
+	if !ActionsView(obj, reflect.TypeOf(obj), vp, pa, ps) {
+		t.Fatalf("ActionsView failed")
+	}
+	if len(pa.Menu) != 1 {
+		t.Fatalf("expected 1 group header action, got %v", len(pa.Menu))
+	}
+	grp := pa.Menu[0].(*gi.Action)
+	if grp.Text != "Show Stuff" {
+		t.Errorf("group label should override camel-case name: %v", grp.Text)
+	}
+	if grp.Icon != "edit" {
+		t.Errorf("group icon should be set: %v", grp.Icon)
+	}
+	if len(grp.Menu) != 2 {
+		t.Errorf("label/icon entries should not become menu items: %v", len(grp.Menu))
+	}
+}
+
+type methViewVisibleObj struct {
+	Advanced bool
+	Ran      bool
+}
+
+func (mv *methViewVisibleObj) BasicIt()    { mv.Ran = true }
+func (mv *methViewVisibleObj) AdvancedIt() { mv.Ran = true }
+
+var KiT_methViewVisibleObj = kit.Types.AddType(&methViewVisibleObj{}, ki.Props{
+	"ToolBar": ki.PropSlice{
+		{Name: "BasicIt", Value: ki.Props{"no-update-after": true}},
+		{Name: "AdvancedIt", Value: ki.Props{
+			"no-update-after": true,
+			"visible-func": func(it interface{}) bool {
+				return it.(*methViewVisibleObj).Advanced
+			},
+		}},
+	},
+})
+
+func TestMethViewVisibleFunc(t *testing.T) {
+	obj := &methViewVisibleObj{}
+	vp := &gi.Viewport2D{}
+	vp.InitName(vp, "test-vp")
+	tb := &gi.ToolBar{}
+	tb.InitName(tb, "toolbar")
+	if !ToolBarView(obj, vp, tb) {
+		t.Fatalf("ToolBarView failed")
+	}
+	if _, has := tb.ChildByName("BasicIt", 0); !has {
+		t.Errorf("unconditional action should be created")
+	}
+	if _, has := tb.ChildByName("AdvancedIt", 0); has {
+		t.Errorf("action with false visible-func should not be created at all")
+	}
+
+	// visible state: action is created, and refresh toggles visibility
+	obj.Advanced = true
+	tb2 := &gi.ToolBar{}
+	tb2.InitName(tb2, "toolbar2")
+	if !ToolBarView(obj, vp, tb2) {
+		t.Fatalf("ToolBarView failed")
+	}
+	aci, has := tb2.ChildByName("AdvancedIt", 0)
+	if !has {
+		t.Fatalf("action with true visible-func should be created")
+	}
+	ac := aci.(*gi.Action)
+	obj.Advanced = false
+	MethViewUpdateFunc(ac)
+	if !ac.IsInvisible() {
+		t.Errorf("refresh should hide action when visible-func turns false")
+	}
+	obj.Advanced = true
+	MethViewUpdateFunc(ac)
+	if ac.IsInvisible() {
+		t.Errorf("refresh should show action when visible-func turns true")
+	}
+}
+
+func TestMethViewMenuGroups(t *testing.T) {
+	obj := &methViewShowInObj{}
+	vp := &gi.Viewport2D{}
+	vp.InitName(vp, "test-vp")
+	pa := &gi.Action{}
+	pa.InitName(pa, "File")
+	ps := ki.PropSlice{
+		{Name: "group-show", Value: ki.PropSlice{
+			{Name: "ShowIt", Value: ki.Props{"no-update-after": true}},
+		}},
+		{Name: "group-hide", Value: ki.PropSlice{
+			{Name: "HideIt", Value: ki.Props{"no-update-after": true}},
+		}},
+	}
+	if !ActionsView(obj, reflect.TypeOf(obj), vp, pa, ps) {
+		t.Fatalf("ActionsView failed")
+	}
+	if len(pa.Menu) != 3 {
+		t.Fatalf("expected 2 actions + 1 separator, got %v items", len(pa.Menu))
+	}
+	if pa.Menu[0].Name() != "ShowIt" {
+		t.Errorf("first group action should be inline: %v", pa.Menu[0].Name())
+	}
+	if _, ok := pa.Menu[1].(*gi.Separator); !ok {
+		t.Errorf("separator should be inserted between groups: %T", pa.Menu[1])
+	}
+	if pa.Menu[2].Name() != "HideIt" {
+		t.Errorf("second group action should follow separator: %v", pa.Menu[2].Name())
+	}
+}
+
+func TestMethViewThrottleUpdate(t *testing.T) {
+	svRender := MethViewRender
+	svWindow := MethViewUpdateWindow
+	var mu sync.Mutex
+	nrender := 0
+	MethViewRender = func(vp *gi.Viewport2D) {
+		mu.Lock()
+		nrender++
+		mu.Unlock()
+	}
+	MethViewUpdateWindow = 20 * time.Millisecond
+	defer func() {
+		MethViewRender = svRender
+		MethViewUpdateWindow = svWindow
+	}()
+
+	obj := &methViewTestObj{}
+	md := newMethViewTestData(obj)
+	bitflag.Clear32((*int32)(&md.Flags), int(MethViewNoUpdateAfter))
+	bitflag.Set32((*int32)(&md.Flags), int(MethViewThrottleUpdate))
+
+	// several rapid calls coalesce into a single render
+	_, args, _, _ := MethViewArgData(md)
+	args[0].SetInt(1)
+	for i := 0; i < 5; i++ {
+		MethViewCallMeth(md, args)
+	}
+	time.Sleep(4 * MethViewUpdateWindow)
+	mu.Lock()
+	got := nrender
+	mu.Unlock()
+	if got != 1 {
+		t.Errorf("rapid calls should coalesce to a single render, got %v", got)
+	}
+
+	// without the flag, every call renders
+	bitflag.Clear32((*int32)(&md.Flags), int(MethViewThrottleUpdate))
+	MethViewCallMeth(md, args)
+	MethViewCallMeth(md, args)
+	mu.Lock()
+	got = nrender
+	mu.Unlock()
+	if got != 3 {
+		t.Errorf("unthrottled calls should each render, got %v total", got)
+	}
+}
+
+func (mv *methViewTestObj) SetSecret(tok string) {
+	mv.Secret = tok
+}
+
+func TestMethViewPasswordArg(t *testing.T) {
+	obj := &methViewTestObj{}
+	vval := reflect.ValueOf(obj)
+	mtyp, _ := reflect.TypeOf(obj).MethodByName("SetSecret")
+	md := &MethViewData{
+		Val:      obj,
+		ValVal:   vval,
+		Method:   "SetSecret",
+		MethVal:  vval.MethodByName("SetSecret"),
+		MethTyp:  mtyp,
+		ArgProps: ki.PropSlice{{Name: "tok", Value: ki.Props{"password": true}}},
+	}
+	bitflag.Set32((*int32)(&md.Flags), int(MethViewNoUpdateAfter))
+	ads, args, nprompt, ok := MethViewArgData(md)
+	if !ok || nprompt != 1 {
+		t.Fatalf("MethViewArgData failed: ok = %v, nprompt = %v", ok, nprompt)
+	}
+	if tag, has := ads[0].View.Tag("password"); !has || tag != "true" {
+		t.Fatalf("password arg prop should set password tag on view: %q, %v", tag, has)
+	}
+	tf := &gi.TextField{}
+	tf.InitName(tf, "tok")
+	ads[0].View.ConfigWidget(tf)
+	if !tf.NoEcho {
+		t.Fatalf("password arg view should configure masked (NoEcho) display")
+	}
+	if string(tf.DisplayRunes([]rune("hunter2"))) != "•••••••" {
+		t.Errorf("masked display should show bullets: %q", string(tf.DisplayRunes([]rune("hunter2"))))
+	}
+	// the actual value still goes through in cleartext
+	args[0].SetString("hunter2")
+	MethViewCallMeth(md, args)
+	if obj.Secret != "hunter2" {
+		t.Errorf("method should receive cleartext value: %q", obj.Secret)
+	}
+}
@@ -9,9 +9,9 @@ import (
 
 var _ = errors.New("dummy error")
 
-const _MethViewFlags_name = "MethViewConfirmMethViewShowReturnMethViewNoUpdateAfterMethViewHasSubMenuMethViewHasSubMenuValMethViewKeyFunMethViewFlagsN"
+const _MethViewFlags_name = "MethViewConfirmMethViewShowReturnMethViewNoUpdateAfterMethViewHasSubMenuMethViewHasSubMenuValMethViewKeyFunMethViewRememberArgsMethViewCtxArgMethViewViewReturnMethViewThrottleUpdateMethViewFlagsN"
 
-var _MethViewFlags_index = [...]uint8{0, 15, 33, 54, 72, 93, 107, 121}
+var _MethViewFlags_index = [...]uint8{0, 15, 33, 54, 72, 93, 107, 127, 141, 159, 181, 195}
 
 func (i MethViewFlags) String() string {
 	if i < 0 || i >= MethViewFlags(len(_MethViewFlags_index)-1) {
@@ -156,7 +156,12 @@ func ToValueView(it interface{}, tags string) ValueView {
 
 	switch {
 	case vk >= reflect.Int && vk <= reflect.Uint64:
-		if kit.Enums.TypeRegistered(nptyp) { // todo: bitfield
+		if kit.Enums.TypeRegistered(nptyp) {
+			if kit.Enums.IsBitFlag(nptyp) {
+				vv := BitFlagValueView{}
+				vv.Init(&vv)
+				return &vv
+			}
 			vv := EnumValueView{}
 			vv.Init(&vv)
 			return &vv
@@ -754,6 +759,12 @@ func (vv *ValueViewBase) ConfigWidget(widg gi.Node2D) {
 			tf.SetProp("max-width", units.NewValue(width, units.Ch))
 		}
 	}
+	if pwtag, ok := vv.Tag("password"); ok {
+		if pw, _ := kit.ToBool(pwtag); pw {
+			tf.NoEcho = true
+			tf.SetProp("no-echo", true) // keep through styling
+		}
+	}
 	if completetag, ok := vv.Tag("complete"); ok {
 		in := []reflect.Value{reflect.ValueOf(tf)}
 		in = append(in, reflect.ValueOf(completetag)) // pass tag value - object may doing completion on multiple fields
@@ -1,6 +1,9 @@
 module github.com/goki/gi
 
+go 1.21.6
+
 require (
+	github.com/BurntSushi/xgb v0.0.0-20210121224620-deaf085860bc
 	github.com/Knetic/govaluate v3.0.0+incompatible
 	github.com/Masterminds/vcs v1.12.0
 	github.com/alecthomas/chroma v0.6.2
@@ -13,7 +16,6 @@ require (
 	github.com/goki/ki v0.9.5
 	github.com/goki/pi v0.5.6
 	github.com/goki/prof v0.0.0-20180502205428-54bc71b5d09b
-	github.com/gorilla/css v1.0.0 // indirect
 	github.com/iancoleman/strcase v0.0.0-20180726023541-3605ed457bf7
 	github.com/mitchellh/go-homedir v1.0.0
 	github.com/pmezard/go-difflib v1.0.0
@@ -23,5 +25,26 @@ require (
 	golang.org/x/image v0.0.0-20181116024801-cd38e8056d9b
 	golang.org/x/mobile v0.0.0-20190103144551-9a2b4796a4b7
 	golang.org/x/net v0.0.0-20181220203305-927f97764cc3
+)
+
+require (
+	github.com/akutz/sortfold v0.2.1 // indirect
+	github.com/alecthomas/assert v0.0.0-20170929043011-405dbfeb8e38 // indirect
+	github.com/alecthomas/colour v0.0.0-20160524082231-60882d9e2721 // indirect
+	github.com/alecthomas/kong v0.1.15 // indirect
+	github.com/alecthomas/repr v0.0.0-20181024024818-d37bc2a10ba1 // indirect
+	github.com/danwakefield/fnmatch v0.0.0-20160403171240-cbb64ac3d964 // indirect
+	github.com/davecgh/go-spew v1.1.1 // indirect
+	github.com/dlclark/regexp2 v1.1.6 // indirect
+	github.com/gabriel-vasile/mimetype v0.0.0-20181202175247-077d38b2e891 // indirect
+	github.com/gorilla/css v1.0.0 // indirect
+	github.com/h2non/filetype v1.0.5 // indirect
+	github.com/jinzhu/copier v0.0.0-20180308034124-7e38e58719c3 // indirect
+	github.com/mattn/go-colorable v0.0.9 // indirect
+	github.com/mattn/go-isatty v0.0.4 // indirect
+	github.com/sergi/go-diff v1.0.0 // indirect
+	github.com/stretchr/testify v1.2.2 // indirect
+	golang.org/x/sys v0.0.0-20190102155601-82a175fd1598 // indirect
 	golang.org/x/text v0.3.0 // indirect
+	gopkg.in/h2non/filetype.v1 v1.0.5 // indirect
 )
@@ -184,7 +184,7 @@ func (ch Chord) Shortcut() string {
 // OSShortcut translates Command into either Control or Meta depending on platform
 func (ch Chord) OSShortcut() Chord {
 	sc := string(ch)
-	if oswin.TheApp.Platform() == oswin.MacOS {
+	if oswin.TheApp != nil && oswin.TheApp.Platform() == oswin.MacOS {
 		sc = strings.Replace(sc, "Command+", "Meta+", -1)
 	} else {
 		sc = strings.Replace(sc, "Command+", "Control+", -1)
@@ -111,6 +111,9 @@ type Window interface {
 	// IsFullscreen returns true if this is a fullscreen window.
 	IsFullscreen() bool
 
+	// IsFixedSize returns true if this window cannot be resized by the user.
+	IsFixedSize() bool
+
 	// IsMinimized returns true if this window is minimized.
 	IsMinimized() bool
 
@@ -215,6 +218,10 @@ func (w *WindowBase) IsFullscreen() bool {
 	return bitflag.HasAtomic(&w.Flag, int(Fullscreen))
 }
 
+func (w *WindowBase) IsFixedSize() bool {
+	return bitflag.HasAtomic(&w.Flag, int(FixedSize))
+}
+
 func (w *WindowBase) IsMinimized() bool {
 	return bitflag.HasAtomic(&w.Flag, int(Minimized))
 }
@@ -255,6 +262,11 @@ const (
 	// Focus indicates that the window has the focus.
 	Focus
 
+	// FixedSize indicates a window whose size is fixed at creation and
+	// cannot be resized by the user -- drivers set the corresponding
+	// window-manager resize hints where supported.
+	FixedSize
+
 	WindowFlagsN
 )
 
@@ -301,6 +313,10 @@ func (o *NewWindowOptions) SetFullscreen() {
 	bitflag.Set(&o.Flags, int(Fullscreen))
 }
 
+func (o *NewWindowOptions) SetFixedSize() {
+	bitflag.Set(&o.Flags, int(FixedSize))
+}
+
 func WindowFlagsToBool(flags int64) (dialog, modal, tool, fullscreen bool) {
 	dialog = bitflag.Has(flags, int(Dialog))
 	modal = bitflag.Has(flags, int(Modal))
@@ -7,9 +7,9 @@ import (
 	"strconv"
 )
 
-const _WindowFlags_name = "DialogModalToolFullscreenMinimizedFocusWindowFlagsN"
+const _WindowFlags_name = "DialogModalToolFullscreenMinimizedFocusFixedSizeWindowFlagsN"
 
-var _WindowFlags_index = [...]uint8{0, 6, 11, 15, 25, 34, 39, 51}
+var _WindowFlags_index = [...]uint8{0, 6, 11, 15, 25, 34, 39, 48, 60}
 
 func (i WindowFlags) String() string {
 	if i < 0 || i >= WindowFlags(len(_WindowFlags_index)-1) {
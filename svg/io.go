@@ -36,6 +36,25 @@ var (
 	missingIdError      = errors.New("gi.SVG Parse: cannot find id")
 )
 
+// ReadPointPairs reads an SVG "points" attribute string (as used by polygon
+// and polyline) into Vec2D coordinate pairs, returning an error if there is
+// an odd number of values -- empty input returns nil, nil
+func ReadPointPairs(pstr string) ([]gi.Vec2D, error) {
+	pts := gi.ReadPoints(pstr)
+	if pts == nil {
+		return nil, nil
+	}
+	sz := len(pts)
+	if sz%2 != 0 {
+		return nil, fmt.Errorf("gi.SVG points has an odd number of values: %v str: %v\n", sz, pstr)
+	}
+	pvec := make([]gi.Vec2D, sz/2)
+	for ci := 0; ci < sz/2; ci++ {
+		pvec[ci].Set(pts[ci*2], pts[ci*2+1])
+	}
+	return pvec, nil
+}
+
 // OpenXML Opens XML-formatted SVG input from given file
 func (svg *SVG) OpenXML(filename string) error {
 	fi, err := os.Stat(filename)
@@ -291,18 +310,13 @@ func (svg *SVG) UnmarshalXML(decoder *xml.Decoder, se xml.StartElement) error {
 					}
 					switch attr.Name.Local {
 					case "points":
-						pts := gi.ReadPoints(attr.Value)
-						if pts != nil {
-							sz := len(pts)
-							if sz%2 != 0 {
-								err = fmt.Errorf("gi.SVG polygon has an odd number of points: %v str: %v\n", sz, attr.Value)
-								log.Println(err)
-								return err
-							}
-							pvec := make([]gi.Vec2D, sz/2)
-							for ci := 0; ci < sz/2; ci++ {
-								pvec[ci].Set(pts[ci*2], pts[ci*2+1])
-							}
+						pvec, perr := ReadPointPairs(attr.Value)
+						if perr != nil {
+							err = perr
+							log.Println(err)
+							return err
+						}
+						if pvec != nil {
 							polygon.Points = pvec
 						}
 					default:
@@ -320,18 +334,13 @@ func (svg *SVG) UnmarshalXML(decoder *xml.Decoder, se xml.StartElement) error {
 					}
 					switch attr.Name.Local {
 					case "points":
-						pts := gi.ReadPoints(attr.Value)
-						if pts != nil {
-							sz := len(pts)
-							if sz%2 != 0 {
-								err = fmt.Errorf("gi.SVG polyline has an odd number of points: %v str: %v\n", sz, attr.Value)
-								log.Println(err)
-								return err
-							}
-							pvec := make([]gi.Vec2D, sz/2)
-							for ci := 0; ci < sz/2; ci++ {
-								pvec[ci].Set(pts[ci*2], pts[ci*2+1])
-							}
+						pvec, perr := ReadPointPairs(attr.Value)
+						if perr != nil {
+							err = perr
+							log.Println(err)
+							return err
+						}
+						if pvec != nil {
 							polyline.Points = pvec
 						}
 					default:
@@ -0,0 +1,38 @@
+// Copyright (c) 2019, The GoKi Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package svg
+
+import (
+	"testing"
+
+	"github.com/goki/gi/gi"
+)
+
+func TestReadPointPairs(t *testing.T) {
+	pts, err := ReadPointPairs("0,0 10,0 10,10 0,10")
+	if err != nil {
+		t.Fatalf("even input should parse: %v", err)
+	}
+	exp := []gi.Vec2D{{0, 0}, {10, 0}, {10, 10}, {0, 10}}
+	if len(pts) != len(exp) {
+		t.Fatalf("expected %v pairs, got %v", len(exp), len(pts))
+	}
+	for i := range exp {
+		if pts[i] != exp[i] {
+			t.Errorf("pair %v: expected %v, got %v", i, exp[i], pts[i])
+		}
+	}
+
+	// odd number of values is an error
+	if _, err := ReadPointPairs("0,0 10,0 10"); err == nil {
+		t.Errorf("odd input should return an error")
+	}
+
+	// empty input: no pairs, no error
+	pts, err = ReadPointPairs("")
+	if pts != nil || err != nil {
+		t.Errorf("empty input should return nil, nil: %v, %v", pts, err)
+	}
+}
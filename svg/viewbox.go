@@ -26,6 +26,39 @@ func (vb *ViewBox) Defaults() {
 	vb.PreserveAspectRatio.MeetOrSlice = Meet
 }
 
+// IsDefault returns true if the viewbox is at the defaults set by Defaults()
+// -- e.g., for deciding whether a viewBox needs to be saved
+func (vb ViewBox) IsDefault() bool {
+	return vb.Min.IsZero() && vb.Size.IsZero() &&
+		vb.PreserveAspectRatio.Align == None && vb.PreserveAspectRatio.MeetOrSlice == Meet
+}
+
+// Equals returns true if all fields of the two viewboxes are equal
+func (vb ViewBox) Equals(b ViewBox) bool {
+	return vb == b
+}
+
+// Transform returns the transform mapping the viewbox onto a viewport of
+// given size: stretching each axis independently when Align is None, and
+// otherwise scaling uniformly and centering (Meet) -- a zero-size viewbox
+// gives the identity.  todo: alignment values other than centering are not
+// yet distinguished.
+func (vb *ViewBox) Transform(vpsz gi.Vec2D) gi.Matrix2D {
+	if vb.Size.IsZero() {
+		return gi.Identity2D()
+	}
+	uniform := vb.PreserveAspectRatio.Align != None
+	return gi.RectToRectMatrix(vb.Min, vb.Size, gi.Vec2DZero, vpsz, uniform)
+}
+
+// ApplyTo computes the transform for the viewport's current Geom.Size and
+// assigns it as the viewport's current render transform, so loading code
+// does not have to thread the matrix manually -- call again from the resize
+// path to recompute after a size change
+func (vb *ViewBox) ApplyTo(vp *gi.Viewport2D) {
+	vp.Render.XForm = vb.Transform(vp.Geom.SizeVec())
+}
+
 // ViewBoxAlign defines values for the PreserveAspectRatio alignment factor
 type ViewBoxAlign int32
 
@@ -0,0 +1,50 @@
+// Copyright (c) 2019, The GoKi Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package svg
+
+import (
+	"image"
+	"testing"
+
+	"github.com/goki/gi/gi"
+)
+
+func TestViewBoxApplyTo(t *testing.T) {
+	var vb ViewBox
+	vb.Defaults()
+	vb.Size = gi.Vec2D{100, 50}
+
+	vp := &gi.Viewport2D{}
+	vp.InitName(vp, "test-vp")
+	vp.Geom.Size = image.Point{200, 100}
+
+	vb.ApplyTo(vp)
+	exp := vb.Transform(gi.Vec2D{200, 100})
+	if vp.Render.XForm != exp {
+		t.Errorf("ApplyTo should assign the Transform matrix: %v != %v", vp.Render.XForm, exp)
+	}
+	// Align None stretches each axis to fill
+	if exp.XX != 2 || exp.YY != 2 || exp.X0 != 0 || exp.Y0 != 0 {
+		t.Errorf("stretch transform wrong: %v", exp)
+	}
+
+	// uniform (non-None align): aspect preserved, centered
+	vb.PreserveAspectRatio.Align = XMid + YMid
+	vp.Geom.Size = image.Point{300, 100}
+	vb.ApplyTo(vp)
+	m := vp.Render.XForm
+	if m.XX != 2 || m.YY != 2 {
+		t.Errorf("uniform scale should use min axis ratio: %v", m)
+	}
+	if m.X0 != 50 || m.Y0 != 0 {
+		t.Errorf("uniform transform should center on X: %v", m)
+	}
+
+	// zero-size viewbox: identity
+	vb.Size = gi.Vec2DZero
+	if vb.Transform(gi.Vec2D{200, 100}) != gi.Identity2D() {
+		t.Errorf("zero-size viewbox should give identity")
+	}
+}